@@ -0,0 +1,140 @@
+package configs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+)
+
+type (
+	Config struct {
+		Views      map[string]View   `json:"views,omitempty"`
+		Columns    []string          `json:"columns,omitempty"`
+		DateFormat string            `json:"date_format,omitempty"`
+		Dates      string            `json:"dates,omitempty"`
+		Locale     string            `json:"locale,omitempty"`
+		Timezone   string            `json:"timezone,omitempty"`
+		Store      string            `json:"store,omitempty"`
+		Color      string            `json:"color,omitempty"`
+		Output     string            `json:"output,omitempty"`
+		Formats    map[string]string `json:"formats,omitempty"`
+		Theme      map[string]string `json:"theme,omitempty"`
+		FileName   string            `json:"-"`
+	}
+
+	View struct {
+		Query string `json:"query"`
+		Sort  string `json:"sort,omitempty"`
+	}
+)
+
+var BuiltinViews = map[string]View{
+	"todo":        {Query: "status:todo"},
+	"in-progress": {Query: "status:in-progress"},
+	"done":        {Query: "status:done"},
+	"today":       {Query: "due:today -status:done", Sort: "-priority"},
+	"overdue":     {Query: "due<today -status:done", Sort: "due,-priority"},
+	"stale":       {Query: "updated<-14d -status:done", Sort: "updated"},
+}
+
+var viewName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// LoadConfig reads the configuration file, a missing file is an empty config.
+func LoadConfig(fileName string) (*Config, error) {
+	config := &Config{FileName: fileName}
+
+	file, err := os.ReadFile(fileName)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(file, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", fileName, err)
+	}
+	return config, nil
+}
+
+func (c *Config) Save() error {
+	file, err := json.MarshalIndent(c, "", " ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.FileName, file, 0644)
+}
+
+// Location is the configured IANA timezone, the system one when unset.
+func (c *Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	return location, nil
+}
+
+func (c *Config) View(name string) (View, bool) {
+	if view, ok := c.Views[name]; ok {
+		return view, true
+	}
+	view, ok := BuiltinViews[name]
+	return view, ok
+}
+
+func (c *Config) SaveView(name string, view View) error {
+	if !viewName.MatchString(name) {
+		return fmt.Errorf("invalid view name %q, use letters, digits, - and _", name)
+	}
+
+	if _, ok := BuiltinViews[name]; ok {
+		return fmt.Errorf("view %q is built in", name)
+	}
+
+	if c.Views == nil {
+		c.Views = map[string]View{}
+	}
+	c.Views[name] = view
+	return c.Save()
+}
+
+func (c *Config) DeleteView(name string) error {
+	if _, ok := c.Views[name]; !ok {
+		return fmt.Errorf("view %q not found", name)
+	}
+
+	delete(c.Views, name)
+	return c.Save()
+}
+
+// ViewNames lists saved views followed by the built-in ones, both sorted.
+func (c *Config) ViewNames() []string {
+	saved := []string{}
+	for name := range c.Views {
+		saved = append(saved, name)
+	}
+	sort.Strings(saved)
+
+	builtin := []string{}
+	for name := range BuiltinViews {
+		builtin = append(builtin, name)
+	}
+	sort.Strings(builtin)
+
+	return slices.Concat(saved, builtin)
+}
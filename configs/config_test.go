@@ -0,0 +1,80 @@
+package configs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	asserts := assert.New(t)
+	fileName := "test-config.json"
+
+	setup := func() {
+		os.Remove(fileName)
+	}
+	defer setup()
+
+	t.Run("✅ Should load an empty config when the file does not exist", func(t *testing.T) {
+		setup()
+
+		config, err := LoadConfig(fileName)
+
+		asserts.Nil(err)
+		asserts.Empty(config.Views)
+		asserts.NoFileExists(fileName)
+	})
+
+	t.Run("✅ Should persist a saved view", func(t *testing.T) {
+		setup()
+
+		config, _ := LoadConfig(fileName)
+		err := config.SaveView("backend", View{Query: "tag:backend", Sort: "-priority"})
+		reloaded, loadErr := LoadConfig(fileName)
+		view, ok := reloaded.View("backend")
+
+		asserts.Nil(err)
+		asserts.Nil(loadErr)
+		asserts.True(ok)
+		asserts.Equal(View{Query: "tag:backend", Sort: "-priority"}, view)
+	})
+
+	t.Run("✅ Should find built-in views", func(t *testing.T) {
+		setup()
+
+		config, _ := LoadConfig(fileName)
+		view, ok := config.View("overdue")
+
+		asserts.True(ok)
+		asserts.Equal("due<today -status:done", view.Query)
+	})
+
+	t.Run("✅ Should list saved views before built-in ones", func(t *testing.T) {
+		setup()
+
+		config, _ := LoadConfig(fileName)
+		config.SaveView("mine", View{Query: "tag:me"})
+
+		asserts.Equal([]string{"mine", "done", "in-progress", "overdue", "stale", "today", "todo"}, config.ViewNames())
+	})
+
+	t.Run("❌ Should not overwrite a built-in view", func(t *testing.T) {
+		setup()
+
+		config, _ := LoadConfig(fileName)
+		err := config.SaveView("today", View{Query: "tag:me"})
+
+		asserts.EqualError(err, `view "today" is built in`)
+		asserts.NoFileExists(fileName)
+	})
+
+	t.Run("❌ Should return an error when deleting a missing view", func(t *testing.T) {
+		setup()
+
+		config, _ := LoadConfig(fileName)
+		err := config.DeleteView("mine")
+
+		asserts.EqualError(err, `view "mine" not found`)
+	})
+}
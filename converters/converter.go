@@ -0,0 +1,122 @@
+package converters
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+const (
+	CSV         = "csv"
+	MARKDOWN    = "markdown"
+	ICS         = "ics"
+	TODOTXT     = "todotxt"
+	TASKWARRIOR = "taskwarrior"
+)
+
+// Formats are the formats tasks can be imported from and exported to.
+var Formats = []string{CSV, MARKDOWN, ICS, TODOTXT, TASKWARRIOR}
+
+// Result holds the tasks read by an importer and notes about the data it
+// could not convert.
+type Result struct {
+	Tasks    []*models.Task
+	Warnings []string
+	// LocalIds is set when the task IDs only link the tasks of the result,
+	// as dependencies do, and never name stored tasks.
+	LocalIds bool
+}
+
+// Renumber gives the tasks IDs from nextId on, in order, and points the
+// dependencies between them at the new IDs. Dependencies on IDs outside
+// the result are kept as they are.
+func (r *Result) Renumber(nextId int) {
+	ids := map[int]int{}
+	for _, task := range r.Tasks {
+		if task.Id > 0 {
+			ids[task.Id] = nextId
+		}
+		task.Id = nextId
+		nextId++
+	}
+
+	for _, task := range r.Tasks {
+		for i, id := range task.DependsOn {
+			if renumbered, ok := ids[id]; ok {
+				task.DependsOn[i] = renumbered
+			}
+		}
+	}
+}
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+func ParseFormat(value string) (string, error) {
+	switch format := strings.ToLower(value); format {
+	case "md":
+		return MARKDOWN, nil
+	case "ical", "icalendar":
+		return ICS, nil
+	case "todo.txt":
+		return TODOTXT, nil
+	case "task":
+		return TASKWARRIOR, nil
+	case CSV, MARKDOWN, ICS, TODOTXT, TASKWARRIOR:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported format %q, expected: %s", value, strings.Join(Formats, ", "))
+}
+
+// DetectFormat picks the format from a file extension.
+func DetectFormat(fileName string) (string, error) {
+	switch extension := strings.ToLower(filepath.Ext(fileName)); extension {
+	case ".csv":
+		return CSV, nil
+	case ".md", ".markdown":
+		return MARKDOWN, nil
+	case ".ics", ".ical":
+		return ICS, nil
+	case ".txt":
+		return TODOTXT, nil
+	}
+	return "", fmt.Errorf("cannot detect the format of %q, use --format", fileName)
+}
+
+func (r *Result) warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// parseTime reads a date in layout, falling back to RFC 3339 and ISO dates.
+func parseTime(value string, layout string) (time.Time, error) {
+	layouts := dateLayouts
+	if layout != "" {
+		layouts = append([]string{layout}, layouts...)
+	}
+
+	for _, layout := range layouts {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func splitTags(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
@@ -0,0 +1,28 @@
+package converters
+
+import (
+	"task-tracker/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	asserts := assert.New(t)
+
+	t.Run("✅ Should renumber tasks and the dependencies between them", func(t *testing.T) {
+		result := &Result{Tasks: []*models.Task{
+			{Id: 1, Description: "Design"},
+			{Id: 2, Description: "Build", DependsOn: []int{1, 40}},
+			{Description: "Ship", DependsOn: []int{2}},
+		}}
+
+		result.Renumber(10)
+
+		asserts.Equal(10, result.Tasks[0].Id)
+		asserts.Equal(11, result.Tasks[1].Id)
+		asserts.Equal(12, result.Tasks[2].Id)
+		asserts.Equal([]int{10, 40}, result.Tasks[1].DependsOn)
+		asserts.Equal([]int{11}, result.Tasks[2].DependsOn)
+	})
+}
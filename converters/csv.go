@@ -0,0 +1,298 @@
+package converters
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"task-tracker/renderers"
+	"time"
+)
+
+const (
+	HeaderAuto = "auto"
+	HeaderYes  = "yes"
+	HeaderNo   = "no"
+)
+
+// CSVOptions shapes the columns of a CSV file. Mapping renames file
+// columns to task fields and Header is auto, yes or no.
+type CSVOptions struct {
+	Columns    []string
+	Mapping    map[string]string
+	Header     string
+	DateFormat string
+}
+
+// CSVFields are the task fields in their default column order. Fields
+// added later go last so files written without header still read back.
+var CSVFields = []string{"id", "description", "status", "priority", "tags", "due", "created_at", "updated_at", "history", "project", "assignee", "depends_on", "notes"}
+
+var csvAliases = map[string]string{
+	"created": "created_at",
+	"updated": "updated_at",
+	"desc":    "description",
+	"tag":     "tags",
+	"depends": "depends_on",
+	"note":    "notes",
+}
+
+// ParseMapping reads "File column=field" pairs separated by commas.
+func ParseMapping(spec string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		column, field, ok := strings.Cut(pair, "=")
+		field = csvField(field)
+		if !ok || !slices.Contains(CSVFields, field) {
+			return nil, fmt.Errorf("invalid mapping %q, expected column=field with a field among: %s", pair, strings.Join(CSVFields, ", "))
+		}
+		mapping[strings.ToLower(strings.TrimSpace(column))] = field
+	}
+	return mapping, nil
+}
+
+func ParseCSVColumns(spec string) ([]string, error) {
+	columns := []string{}
+	for _, name := range strings.Split(spec, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		field := csvField(name)
+		if !slices.Contains(CSVFields, field) {
+			return nil, fmt.Errorf("unknown field %q, expected: %s", name, strings.Join(CSVFields, ", "))
+		}
+		columns = append(columns, field)
+	}
+	return columns, nil
+}
+
+// WriteCSV writes a header and a row per task with every field by default.
+func WriteCSV(w io.Writer, tasks []*models.Task, options CSVOptions) error {
+	columns := options.Columns
+	if len(columns) == 0 {
+		columns = CSVFields
+	}
+
+	writer := csv.NewWriter(w)
+	if options.Header != HeaderNo {
+		writer.Write(columns)
+	}
+
+	for _, task := range tasks {
+		row := []string{}
+		for _, column := range columns {
+			row = append(row, csvValue(task, column, options.DateFormat))
+		}
+		writer.Write(row)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCSV reads tasks from CSV. With Header auto the first row is a header
+// when one of its cells names a task field, otherwise the columns follow
+// Columns or the default order.
+func ReadCSV(r io.Reader, options CSVOptions) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Tasks: []*models.Task{}}
+	if len(rows) == 0 {
+		return result, nil
+	}
+
+	columns := options.Columns
+	if len(columns) == 0 {
+		columns = CSVFields
+	}
+
+	header := []string{}
+	for _, cell := range rows[0] {
+		header = append(header, options.field(cell))
+	}
+
+	first := 0
+	if options.Header == HeaderYes || (options.Header != HeaderNo && slices.ContainsFunc(header, isCSVField)) {
+		columns = header
+		first = 1
+		for i, field := range header {
+			if !isCSVField(field) {
+				result.warn("column %q is not a task field and was skipped", rows[0][i])
+			}
+		}
+	}
+
+	for i, row := range rows[first:] {
+		task := &models.Task{}
+		for j, value := range row {
+			if j >= len(columns) || strings.TrimSpace(value) == "" {
+				continue
+			}
+
+			err := setCSVValue(task, columns[j], strings.TrimSpace(value), options.DateFormat)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", first+i+1, err)
+			}
+		}
+
+		if task.Description == "" {
+			result.warn("line %d has no description and was skipped", first+i+1)
+			continue
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+	return result, nil
+}
+
+func (o CSVOptions) field(column string) string {
+	if field, ok := o.Mapping[strings.ToLower(strings.TrimSpace(column))]; ok {
+		return field
+	}
+	return csvField(column)
+}
+
+func csvField(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(strings.ReplaceAll(name, " ", "_"), "-", "_")
+	if alias, ok := csvAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+func isCSVField(name string) bool {
+	return slices.Contains(CSVFields, name)
+}
+
+func csvValue(task *models.Task, column string, layout string) string {
+	formatDate := func(date *time.Time) string {
+		switch {
+		case date == nil:
+			return ""
+		case layout != "":
+			return date.Format(layout)
+		}
+		return date.Format(time.RFC3339)
+	}
+
+	switch column {
+	case "id":
+		return strconv.Itoa(task.Id)
+	case "description":
+		return task.Description
+	case "status":
+		return renderers.StatusElement(task.Status)
+	case "priority":
+		return strings.ToLower(task.Priority.String())
+	case "tags":
+		return strings.Join(task.Tags, ",")
+	case "project":
+		return task.Project
+	case "assignee":
+		return task.Assignee
+	case "depends_on":
+		ids := []string{}
+		for _, id := range task.DependsOn {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		return strings.Join(ids, ",")
+	case "notes":
+		return task.Notes
+	case "due":
+		return formatDate(task.Due)
+	case "created_at":
+		return formatDate(&task.CreatedAt)
+	case "updated_at":
+		return formatDate(task.UpdatedAt)
+	case "history":
+		changes := []string{}
+		for _, change := range task.History {
+			changes = append(changes, renderers.StatusElement(change.Status)+"@"+change.At.Format(time.RFC3339))
+		}
+		return strings.Join(changes, ";")
+	}
+	return ""
+}
+
+func setCSVValue(task *models.Task, column string, value string, layout string) error {
+	switch column {
+	case "id":
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 0 {
+			return fmt.Errorf("invalid id %q", value)
+		}
+		task.Id = id
+	case "description":
+		task.Description = value
+	case "status":
+		status, err := models.ParseStatus(value)
+		if err != nil {
+			return err
+		}
+		task.Status = status
+	case "priority":
+		priority, err := models.ParsePriority(value)
+		if err != nil {
+			return err
+		}
+		task.Priority = priority
+	case "tags":
+		task.Tags = splitTags(value)
+	case "project":
+		task.Project = value
+	case "assignee":
+		task.Assignee = value
+	case "depends_on":
+		dependsOn, err := models.ParseDependencies(value)
+		if err != nil {
+			return err
+		}
+		task.DependsOn = dependsOn
+	case "notes":
+		task.Notes = value
+	case "due", "created_at", "updated_at":
+		date, err := parseTime(value, layout)
+		if err != nil {
+			return err
+		}
+		switch column {
+		case "due":
+			task.Due = &date
+		case "created_at":
+			task.CreatedAt = date
+		case "updated_at":
+			task.UpdatedAt = &date
+		}
+	case "history":
+		for _, change := range strings.Split(value, ";") {
+			status, at, ok := strings.Cut(strings.TrimSpace(change), "@")
+			if !ok {
+				return fmt.Errorf("invalid history %q, expected status@date", change)
+			}
+			parsed, err := models.ParseStatus(status)
+			if err != nil {
+				return err
+			}
+			date, err := parseTime(at, layout)
+			if err != nil {
+				return err
+			}
+			task.History = append(task.History, models.StatusChange{Status: parsed, At: date})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package converters
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTask(id int, status models.Status) *models.Task {
+	due := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	return &models.Task{
+		Id:          id,
+		Description: fmt.Sprintf("Task, number %d", id),
+		Status:      status,
+		Priority:    models.HIGH,
+		Tags:        []string{"backend", "api"},
+		Due:         &due,
+		CreatedAt:   time.Date(2024, 8, 20, 9, 30, 0, 0, time.UTC),
+		History:     []models.StatusChange{{Status: status, At: time.Date(2024, 8, 21, 10, 0, 0, 0, time.UTC)}},
+	}
+}
+
+func TestCSV(t *testing.T) {
+	asserts := assert.New(t)
+
+	t.Run("✅ Should round trip every task field", func(t *testing.T) {
+		tasks := []*models.Task{createTask(1, models.DONE), createTask(2, models.IN_PROGRESS)}
+		tasks[1].Project = "Billing"
+		tasks[1].Assignee = "sam"
+		tasks[1].DependsOn = []int{1}
+		tasks[1].Notes = "Ask finance, then \"ship\"\nSecond line"
+
+		var buffer bytes.Buffer
+		asserts.NoError(WriteCSV(&buffer, tasks, CSVOptions{}))
+		asserts.True(strings.HasPrefix(buffer.String(), "id,description,status,priority,tags,due,created_at,updated_at,history,project,assignee,depends_on,notes\n"))
+
+		result, err := ReadCSV(&buffer, CSVOptions{})
+		asserts.NoError(err)
+		asserts.Empty(result.Warnings)
+		asserts.Len(result.Tasks, 2)
+		for i, task := range result.Tasks {
+			asserts.Equal(tasks[i].Id, task.Id)
+			asserts.Equal(tasks[i].Description, task.Description)
+			asserts.Equal(tasks[i].Status, task.Status)
+			asserts.Equal(tasks[i].Priority, task.Priority)
+			asserts.Equal(tasks[i].Tags, task.Tags)
+			asserts.Equal(tasks[i].Project, task.Project)
+			asserts.Equal(tasks[i].Assignee, task.Assignee)
+			asserts.Equal(tasks[i].DependsOn, task.DependsOn)
+			asserts.Equal(tasks[i].Notes, task.Notes)
+			asserts.True(tasks[i].Due.Equal(*task.Due))
+			asserts.True(tasks[i].CreatedAt.Equal(task.CreatedAt))
+			asserts.Len(task.History, 1)
+			asserts.Equal(tasks[i].Status, task.History[0].Status)
+		}
+	})
+
+	t.Run("✅ Should map columns and parse dates with a layout", func(t *testing.T) {
+		mapping, err := ParseMapping("Title=description,State=status,Deadline=due")
+		asserts.NoError(err)
+
+		result, err := ReadCSV(strings.NewReader("Title,State,Deadline,Color\nBuy milk,in progress,02/09/2024,x\n,done,,\n"), CSVOptions{
+			Mapping:    mapping,
+			DateFormat: "02/01/2006",
+		})
+
+		asserts.NoError(err)
+		asserts.Len(result.Tasks, 1)
+		asserts.Equal("Buy milk", result.Tasks[0].Description)
+		asserts.Equal(models.IN_PROGRESS, result.Tasks[0].Status)
+		asserts.Equal(time.September, result.Tasks[0].Due.Month())
+		asserts.Equal(2, result.Tasks[0].Due.Day())
+		asserts.Equal([]string{
+			`column "Color" is not a task field and was skipped`,
+			"line 3 has no description and was skipped",
+		}, result.Warnings)
+	})
+
+	t.Run("✅ Should read files without header in the given column order", func(t *testing.T) {
+		result, err := ReadCSV(strings.NewReader("Write docs,todo\n"), CSVOptions{Columns: []string{"description", "status"}})
+
+		asserts.NoError(err)
+		asserts.Len(result.Tasks, 1)
+		asserts.Equal("Write docs", result.Tasks[0].Description)
+	})
+
+	t.Run("❌ Should report the line of invalid values", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader("description,status\nOne,todo\nTwo,blocked\n"), CSVOptions{})
+
+		asserts.EqualError(err, `line 3: invalid status "blocked", expected: todo, in-progress, done`)
+
+		_, err = ParseMapping("Title=owner")
+		asserts.Error(err)
+	})
+}
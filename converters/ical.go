@@ -0,0 +1,338 @@
+package converters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+const (
+	icsDateTime = "20060102T150405Z"
+	icsDate     = "20060102"
+	icsLocal    = "20060102T150405"
+	icsLineSize = 75
+)
+
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+var (
+	icsUid     = regexp.MustCompile(`^task-(\d+)@task-tracker$`)
+	icsEscapes = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	icsIgnored = []string{"UID", "DTSTAMP", "SEQUENCE", "PERCENT-COMPLETE", "CLASS", "URL", "ORGANIZER"}
+)
+
+// WriteICS writes tasks as VTODO components of one calendar, stamped at now.
+func WriteICS(w io.Writer, tasks []*models.Task, now time.Time) error {
+	writer := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writer.WriteString(foldLine(name+":"+value) + "\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//task-tracker//task-cli//EN")
+
+	for _, task := range tasks {
+		line("BEGIN", "VTODO")
+		line("UID", fmt.Sprintf("task-%d@task-tracker", task.Id))
+		line("DTSTAMP", now.UTC().Format(icsDateTime))
+		line("SUMMARY", icsEscapes.Replace(task.Description))
+		if task.Notes != "" {
+			line("DESCRIPTION", icsEscapes.Replace(task.Notes))
+		}
+		line("STATUS", icsStatus(task.Status))
+		if priority := icsPriority(task.Priority); priority > 0 {
+			line("PRIORITY", strconv.Itoa(priority))
+		}
+		if task.Due != nil {
+			if isMidnight(*task.Due) {
+				line("DUE;VALUE=DATE", task.Due.Local().Format(icsDate))
+			} else {
+				line("DUE", task.Due.UTC().Format(icsDateTime))
+			}
+		}
+		line("CREATED", task.CreatedAt.UTC().Format(icsDateTime))
+		if task.UpdatedAt != nil {
+			line("LAST-MODIFIED", task.UpdatedAt.UTC().Format(icsDateTime))
+		}
+		if completed := task.CompletedAt(); completed != nil {
+			line("COMPLETED", completed.UTC().Format(icsDateTime))
+		}
+		if len(task.Tags) > 0 {
+			categories := []string{}
+			for _, tag := range task.Tags {
+				categories = append(categories, icsEscapes.Replace(tag))
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		line("END", "VTODO")
+	}
+
+	line("END", "VCALENDAR")
+	return writer.Flush()
+}
+
+// ReadICS reads the VTODO components of a calendar. UIDs written by
+// WriteICS keep their task ID, cancelled todos count as done and
+// properties without a task field are reported as warnings. DESCRIPTION
+// fills the notes, or the description when SUMMARY is missing. Components nested in a VTODO, such
+// as VALARM, are skipped with their properties.
+func ReadICS(r io.Reader) (*Result, error) {
+	properties, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Tasks: []*models.Task{}}
+	skipped := map[string]bool{}
+	var task *models.Task
+	depth := 0
+
+	for _, property := range properties {
+		switch {
+		case property.name == "BEGIN" && task != nil:
+			if depth++; !skipped["BEGIN:"+strings.ToUpper(property.value)] {
+				skipped["BEGIN:"+strings.ToUpper(property.value)] = true
+				result.warn("component %s inside a VTODO was skipped", strings.ToUpper(property.value))
+			}
+			continue
+		case property.name == "END" && depth > 0:
+			depth--
+			continue
+		case depth > 0:
+			continue
+		case property.name == "BEGIN" && strings.EqualFold(property.value, "VTODO"):
+			task = &models.Task{Status: models.TODO}
+			continue
+		case property.name == "END" && strings.EqualFold(property.value, "VTODO") && task != nil:
+			if task.Description == "" {
+				task.Description, task.Notes = task.Notes, ""
+			}
+			if task.Description == "" {
+				result.warn("a VTODO without SUMMARY was skipped")
+			} else {
+				result.Tasks = append(result.Tasks, task)
+			}
+			task = nil
+			continue
+		case task == nil:
+			continue
+		}
+
+		err := setICSProperty(task, property)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", property.name, err)
+		}
+
+		if !slices.Contains(icsProperties, property.name) && !skipped[property.name] {
+			skipped[property.name] = true
+			result.warn("property %s has no task field and was skipped", property.name)
+		}
+	}
+	return result, nil
+}
+
+var icsProperties = append([]string{"SUMMARY", "DESCRIPTION", "STATUS", "PRIORITY", "DUE", "CREATED", "LAST-MODIFIED", "COMPLETED", "CATEGORIES"}, icsIgnored...)
+
+func setICSProperty(task *models.Task, property icsProperty) error {
+	value := property.value
+
+	switch property.name {
+	case "UID":
+		if match := icsUid.FindStringSubmatch(value); match != nil {
+			task.Id, _ = strconv.Atoi(match[1])
+		}
+	case "SUMMARY":
+		task.Description = unescapeICS(value)
+	case "DESCRIPTION":
+		task.Notes = unescapeICS(value)
+	case "STATUS":
+		switch strings.ToUpper(value) {
+		case "IN-PROCESS":
+			task.Status = models.IN_PROGRESS
+		case "COMPLETED", "CANCELLED":
+			task.Status = models.DONE
+		default:
+			task.Status = models.TODO
+		}
+	case "PRIORITY":
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid priority %q", value)
+		}
+		switch {
+		case priority >= 1 && priority <= 4:
+			task.Priority = models.HIGH
+		case priority == 5:
+			task.Priority = models.MEDIUM
+		case priority >= 6 && priority <= 9:
+			task.Priority = models.LOW
+		}
+	case "DUE", "CREATED", "LAST-MODIFIED", "COMPLETED":
+		date, err := parseICSTime(property)
+		if err != nil {
+			return err
+		}
+		switch property.name {
+		case "DUE":
+			task.Due = &date
+		case "CREATED":
+			task.CreatedAt = date
+		case "LAST-MODIFIED":
+			task.UpdatedAt = &date
+		case "COMPLETED":
+			task.History = append(task.History, models.StatusChange{Status: models.DONE, At: date})
+		}
+	case "CATEGORIES":
+		for _, category := range splitICS(value) {
+			if category = strings.TrimSpace(category); category != "" && !task.HasTag(category) {
+				task.Tags = append(task.Tags, category)
+			}
+		}
+	}
+	return nil
+}
+
+func parseICSTime(property icsProperty) (time.Time, error) {
+	value := property.value
+	if property.params["VALUE"] == "DATE" || len(value) == len(icsDate) {
+		return time.ParseInLocation(icsDate, value, time.Local)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icsDateTime, value)
+	}
+
+	location := time.Local
+	if tzid, ok := property.params["TZID"]; ok {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+	return time.ParseInLocation(icsLocal, value, location)
+}
+
+// unfoldLines joins continuation lines and splits each content line into
+// its name, parameters and value.
+func unfoldLines(r io.Reader) ([]icsProperty, error) {
+	scanner := bufio.NewScanner(r)
+	lines := []string{}
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	properties := []icsProperty{}
+	for i, line := range lines {
+		head, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid calendar line %d: %q", i+1, line)
+		}
+
+		parts := strings.Split(head, ";")
+		property := icsProperty{name: strings.ToUpper(parts[0]), params: map[string]string{}, value: value}
+		for _, param := range parts[1:] {
+			key, paramValue, _ := strings.Cut(param, "=")
+			property.params[strings.ToUpper(key)] = strings.Trim(paramValue, `"`)
+		}
+		properties = append(properties, property)
+	}
+	return properties, nil
+}
+
+// foldLine splits lines longer than 75 octets without breaking characters.
+func foldLine(line string) string {
+	var builder strings.Builder
+	size := 0
+	for _, r := range line {
+		length := len(string(r))
+		if size+length > icsLineSize {
+			builder.WriteString("\r\n ")
+			size = 1
+		}
+		builder.WriteRune(r)
+		size += length
+	}
+	return builder.String()
+}
+
+func unescapeICS(value string) string {
+	var builder strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped && (r == 'n' || r == 'N'):
+			builder.WriteRune('\n')
+		case escaped:
+			builder.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			continue
+		default:
+			builder.WriteRune(r)
+		}
+		escaped = false
+	}
+	return builder.String()
+}
+
+// splitICS splits a list value on commas that are not escaped.
+func splitICS(value string) []string {
+	values := []string{}
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, unescapeICS(value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(values, unescapeICS(value[start:]))
+}
+
+func icsStatus(status models.Status) string {
+	switch status {
+	case models.IN_PROGRESS:
+		return "IN-PROCESS"
+	case models.DONE:
+		return "COMPLETED"
+	}
+	return "NEEDS-ACTION"
+}
+
+func icsPriority(priority models.Priority) int {
+	switch priority {
+	case models.HIGH:
+		return 1
+	case models.MEDIUM:
+		return 5
+	case models.LOW:
+		return 9
+	}
+	return 0
+}
+
+func isMidnight(date time.Time) bool {
+	date = date.Local()
+	return date.Hour() == 0 && date.Minute() == 0 && date.Second() == 0 && date.Nanosecond() == 0
+}
@@ -0,0 +1,112 @@
+package converters
+
+import (
+	"bytes"
+	"strings"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestICS(t *testing.T) {
+	asserts := assert.New(t)
+	now := time.Date(2024, 8, 28, 12, 0, 0, 0, time.UTC)
+
+	t.Run("✅ Should export VTODO entries", func(t *testing.T) {
+		task := createTask(1, models.DONE)
+		task.Description = "Call, the; plumber"
+
+		var buffer bytes.Buffer
+		asserts.NoError(WriteICS(&buffer, []*models.Task{task}, now))
+
+		asserts.Contains(buffer.String(), "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n")
+		asserts.Contains(buffer.String(), "UID:task-1@task-tracker\r\n")
+		asserts.Contains(buffer.String(), "SUMMARY:Call\\, the\\; plumber\r\n")
+		asserts.Contains(buffer.String(), "STATUS:COMPLETED\r\n")
+		asserts.Contains(buffer.String(), "PRIORITY:1\r\n")
+		asserts.Contains(buffer.String(), "CREATED:20240820T093000Z\r\n")
+		asserts.Contains(buffer.String(), "COMPLETED:20240821T100000Z\r\n")
+		asserts.Contains(buffer.String(), "CATEGORIES:backend,api\r\n")
+	})
+
+	t.Run("✅ Should round trip tasks", func(t *testing.T) {
+		tasks := []*models.Task{createTask(1, models.TODO), createTask(2, models.IN_PROGRESS)}
+		tasks[1].Description = strings.Repeat("long ", 30)
+		tasks[1].Notes = "Ask, then; wait\nTwice"
+
+		var buffer bytes.Buffer
+		asserts.NoError(WriteICS(&buffer, tasks, now))
+		for _, line := range strings.Split(buffer.String(), "\r\n") {
+			asserts.LessOrEqual(len(line), 75)
+		}
+
+		result, err := ReadICS(&buffer)
+		asserts.NoError(err)
+		asserts.Empty(result.Warnings)
+		asserts.Len(result.Tasks, 2)
+		for i, task := range result.Tasks {
+			asserts.Equal(tasks[i].Id, task.Id)
+			asserts.Equal(tasks[i].Description, task.Description)
+			asserts.Equal(tasks[i].Notes, task.Notes)
+			asserts.Equal(tasks[i].Status, task.Status)
+			asserts.Equal(tasks[i].Priority, task.Priority)
+			asserts.Equal(tasks[i].Tags, task.Tags)
+			asserts.True(tasks[i].CreatedAt.Equal(task.CreatedAt))
+		}
+	})
+
+	t.Run("✅ Should import todos from other calendar apps", func(t *testing.T) {
+		calendar := "BEGIN:VCALENDAR\n" +
+			"BEGIN:VEVENT\nSUMMARY:Meeting\nEND:VEVENT\n" +
+			"BEGIN:VTODO\n" +
+			"UID:0f3c-42@example.com\n" +
+			"SUMMARY:Renew\n  passport\n" +
+			"DESCRIPTION:Bring two photos\\nand the old one\n" +
+			"DUE;TZID=Europe/Madrid:20240901T090000\n" +
+			"PRIORITY:7\n" +
+			"CATEGORIES:home\n" +
+			"CATEGORIES:admin\\,paperwork\n" +
+			"LOCATION:Town hall\n" +
+			"END:VTODO\n" +
+			"END:VCALENDAR\n"
+
+		result, err := ReadICS(strings.NewReader(calendar))
+
+		asserts.NoError(err)
+		asserts.Len(result.Tasks, 1)
+		task := result.Tasks[0]
+		asserts.Equal(0, task.Id)
+		asserts.Equal("Renew passport", task.Description)
+		asserts.Equal("Bring two photos\nand the old one", task.Notes)
+		asserts.Equal(models.LOW, task.Priority)
+		asserts.Equal([]string{"home", "admin,paperwork"}, task.Tags)
+		asserts.Equal(time.Date(2024, 9, 1, 7, 0, 0, 0, time.UTC), task.Due.UTC())
+		asserts.Equal([]string{"property LOCATION has no task field and was skipped"}, result.Warnings)
+	})
+
+	t.Run("✅ Should skip the components nested in a todo", func(t *testing.T) {
+		calendar := "BEGIN:VCALENDAR\n" +
+			"BEGIN:VTODO\n" +
+			"DESCRIPTION:Water the plants\n" +
+			"BEGIN:VALARM\n" +
+			"ACTION:DISPLAY\n" +
+			"SUMMARY:Alarm\n" +
+			"DESCRIPTION:Reminder\n" +
+			"TRIGGER:-PT15M\n" +
+			"END:VALARM\n" +
+			"STATUS:IN-PROCESS\n" +
+			"END:VTODO\n" +
+			"END:VCALENDAR\n"
+
+		result, err := ReadICS(strings.NewReader(calendar))
+
+		asserts.NoError(err)
+		asserts.Len(result.Tasks, 1)
+		asserts.Equal("Water the plants", result.Tasks[0].Description)
+		asserts.Empty(result.Tasks[0].Notes)
+		asserts.Equal(models.IN_PROGRESS, result.Tasks[0].Status)
+		asserts.Equal([]string{"component VALARM inside a VTODO was skipped"}, result.Warnings)
+	})
+}
@@ -0,0 +1,343 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+const (
+	GroupStatus = "status"
+	GroupTag    = "tag"
+	GroupNone   = "none"
+
+	untagged = "Untagged"
+)
+
+type (
+	// SyncReport counts what a sync changed on each side.
+	SyncReport struct {
+		Added        int `json:"added"`
+		UpdatedTasks int `json:"updated_tasks"`
+		UpdatedLines int `json:"updated_lines"`
+		Appended     int `json:"appended"`
+		Removed      int `json:"removed"`
+		Missing      int `json:"missing"`
+	}
+
+	checklistItem struct {
+		line    int
+		indent  string
+		bullet  string
+		done    bool
+		text    string
+		id      int
+		heading string
+		status  models.Status
+	}
+)
+
+var (
+	checklistLine = regexp.MustCompile(`^(\s*)([-*+]) \[([ xX])\] (.*?)\s*(?:<!--\s*task:(\d+)\s*-->)?\s*$`)
+	headingLine   = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+)
+
+// WriteMarkdown writes tasks as a checklist, each item carrying its task
+// ID in an HTML comment, under a heading per status or tag.
+func WriteMarkdown(w io.Writer, tasks []*models.Task, groupBy string) error {
+	var builder strings.Builder
+
+	for i, group := range groupTasks(tasks, groupBy) {
+		if group.title != "" {
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString("## " + group.title + "\n\n")
+		}
+		for _, task := range group.tasks {
+			builder.WriteString(formatItem(checklistItem{bullet: "-", done: task.Status == models.DONE, text: task.Description, id: task.Id}) + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// ReadMarkdown reads the checklist items of a Markdown file. Checked items
+// are done and unchecked ones take the status of the heading they are
+// under, to do otherwise. Any other heading tags the items under it.
+func ReadMarkdown(r io.Reader) (*Result, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Tasks: []*models.Task{}}
+	for _, item := range parseChecklist(strings.Split(string(content), "\n")) {
+		if item.text == "" {
+			result.warn("line %d has no description and was skipped", item.line+1)
+			continue
+		}
+
+		task := &models.Task{Id: item.id, Description: item.text, Status: models.TODO}
+		if item.done {
+			task.Status = models.DONE
+		} else if item.status != "" && item.status != models.DONE {
+			task.Status = item.status
+		}
+		if item.heading != "" && item.heading != untagged && item.status == "" {
+			task.Tags = []string{item.heading}
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+	return result, nil
+}
+
+// SyncMarkdown reconciles a checklist last modified at modified with all
+// the stored tasks. Items without ID become new tasks and tasks accepted by
+// include but missing from the file are appended. Items whose task is not
+// stored are counted as missing and kept, unless prune removes them. When
+// an item and its task differ, the side changed last wins. It returns the
+// new file content and the tasks to store.
+func SyncMarkdown(content string, modified time.Time, tasks []*models.Task, include func(*models.Task) bool, prune bool, now time.Time) (string, []*models.Task, SyncReport) {
+	report := SyncReport{}
+	lines := []string{}
+	if content = strings.TrimRight(content, "\n"); content != "" {
+		lines = strings.Split(content, "\n")
+	}
+	stored := map[int]*models.Task{}
+	nextId := 1
+	for _, task := range tasks {
+		stored[task.Id] = task
+		nextId = max(nextId, task.Id+1)
+	}
+
+	changed := []*models.Task{}
+	seen := map[int]bool{}
+	removed := map[int]bool{}
+	items := parseChecklist(lines)
+
+	// Items of missing tasks keep their IDs in the file unless pruned, so
+	// new tasks must not reuse them.
+	if !prune {
+		for _, item := range items {
+			nextId = max(nextId, item.id+1)
+		}
+	}
+
+	for _, item := range items {
+		if item.id == 0 {
+			if item.text == "" {
+				continue
+			}
+			task := &models.Task{Id: nextId, Description: item.text, Status: models.TODO, CreatedAt: now}
+			nextId++
+			applyItem(task, item, now)
+			item.id = task.Id
+			lines[item.line] = formatItem(item)
+			changed = append(changed, task)
+			seen[task.Id] = true
+			report.Added++
+			continue
+		}
+
+		task, ok := stored[item.id]
+		if !ok && prune {
+			removed[item.line] = true
+			report.Removed++
+			continue
+		}
+		if !ok {
+			report.Missing++
+			continue
+		}
+
+		seen[task.Id] = true
+		if itemMatches(item, task) {
+			continue
+		}
+
+		if modified.After(lastChange(task)) {
+			applyItem(task, item, now)
+			changed = append(changed, task)
+			report.UpdatedTasks++
+			continue
+		}
+
+		item.done = task.Status == models.DONE
+		item.text = task.Description
+		lines[item.line] = formatItem(item)
+		report.UpdatedLines++
+	}
+
+	// Missing tasks go after the last item under the heading of their
+	// status when the file has one, at the end otherwise.
+	after := map[int][]string{}
+	trailing := []string{}
+	for _, task := range tasks {
+		if seen[task.Id] || (include != nil && !include(task)) {
+			continue
+		}
+		report.Appended++
+
+		last := checklistItem{line: -1, bullet: "-"}
+		for _, item := range items {
+			if item.status != "" && item.status == task.Status && !removed[item.line] {
+				last = item
+			}
+		}
+
+		line := formatItem(checklistItem{indent: last.indent, bullet: last.bullet, done: task.Status == models.DONE, text: task.Description, id: task.Id})
+		if last.line < 0 {
+			trailing = append(trailing, line)
+			continue
+		}
+		after[last.line] = append(after[last.line], line)
+	}
+
+	result := []string{}
+	for i, line := range lines {
+		if !removed[i] {
+			result = append(result, line)
+		}
+		result = append(result, after[i]...)
+	}
+	result = append(result, trailing...)
+
+	return strings.Join(result, "\n") + "\n", changed, report
+}
+
+type group struct {
+	title string
+	tasks []*models.Task
+}
+
+func groupTasks(tasks []*models.Task, groupBy string) []group {
+	groups := []group{}
+	index := map[string]int{}
+	add := func(title string, task *models.Task) {
+		if _, ok := index[title]; !ok {
+			index[title] = len(groups)
+			groups = append(groups, group{title: title})
+		}
+		groups[index[title]].tasks = append(groups[index[title]].tasks, task)
+	}
+
+	switch groupBy {
+	case GroupStatus:
+		for _, status := range []models.Status{models.TODO, models.IN_PROGRESS, models.DONE} {
+			for _, task := range tasks {
+				if task.Status == status {
+					add(status.String(), task)
+				}
+			}
+		}
+		for _, task := range tasks {
+			if task.Status.Rank() == 0 {
+				add(task.Status.String(), task)
+			}
+		}
+	case GroupTag:
+		for _, task := range tasks {
+			if len(task.Tags) > 0 {
+				add(task.Tags[0], task)
+			}
+		}
+		for _, task := range tasks {
+			if len(task.Tags) == 0 {
+				add(untagged, task)
+			}
+		}
+	default:
+		for _, task := range tasks {
+			add("", task)
+		}
+	}
+	return groups
+}
+
+func parseChecklist(lines []string) []checklistItem {
+	items := []checklistItem{}
+	heading := ""
+	var status models.Status
+
+	for i, line := range lines {
+		if match := headingLine.FindStringSubmatch(line); match != nil {
+			heading = match[1]
+			status, _ = models.ParseStatus(heading)
+			continue
+		}
+
+		match := checklistLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		id, _ := strconv.Atoi(match[5])
+		items = append(items, checklistItem{
+			line:    i,
+			indent:  match[1],
+			bullet:  match[2],
+			done:    match[3] != " ",
+			text:    match[4],
+			id:      id,
+			heading: heading,
+			status:  status,
+		})
+	}
+	return items
+}
+
+func formatItem(item checklistItem) string {
+	box := "[ ]"
+	if item.done {
+		box = "[x]"
+	}
+
+	line := fmt.Sprintf("%s%s %s %s", item.indent, item.bullet, box, item.text)
+	if item.id > 0 {
+		line += fmt.Sprintf(" <!-- task:%d -->", item.id)
+	}
+	return line
+}
+
+// itemMatches reports whether an item agrees with its task. An unchecked
+// item outside a status heading agrees with any unfinished status.
+func itemMatches(item checklistItem, task *models.Task) bool {
+	if item.text != task.Description || item.done != (task.Status == models.DONE) {
+		return false
+	}
+	return item.done || item.status == "" || item.status == models.DONE || item.status == task.Status
+}
+
+func applyItem(task *models.Task, item checklistItem, now time.Time) {
+	if task.Description != item.text {
+		task.Description = item.text
+		task.UpdatedAt = &now
+	}
+
+	switch {
+	case item.done:
+		task.MarkAs(models.DONE)
+	case item.status != "" && item.status != models.DONE:
+		task.MarkAs(item.status)
+	case task.Status == models.DONE:
+		task.MarkAs(models.TODO)
+	}
+}
+
+// lastChange is when the task was last edited or moved.
+func lastChange(task *models.Task) time.Time {
+	last := task.CreatedAt
+	if task.UpdatedAt != nil && task.UpdatedAt.After(last) {
+		last = *task.UpdatedAt
+	}
+	if len(task.History) > 0 && task.History[len(task.History)-1].At.After(last) {
+		last = task.History[len(task.History)-1].At
+	}
+	return last
+}
@@ -0,0 +1,108 @@
+package converters
+
+import (
+	"bytes"
+	"strings"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	asserts := assert.New(t)
+	now := time.Date(2024, 8, 28, 12, 0, 0, 0, time.UTC)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{createTask(1, models.TODO), createTask(2, models.IN_PROGRESS), createTask(3, models.DONE)}
+		tasks[0].Tags = nil
+		return tasks
+	}
+
+	t.Run("✅ Should export a checklist grouped by status", func(t *testing.T) {
+		var buffer bytes.Buffer
+		asserts.NoError(WriteMarkdown(&buffer, createTasks(), GroupStatus))
+
+		asserts.Equal("## To do\n\n"+
+			"- [ ] Task, number 1 <!-- task:1 -->\n\n"+
+			"## In progress\n\n"+
+			"- [ ] Task, number 2 <!-- task:2 -->\n\n"+
+			"## Done\n\n"+
+			"- [x] Task, number 3 <!-- task:3 -->\n", buffer.String())
+	})
+
+	t.Run("✅ Should import checklist items with their heading status or tag", func(t *testing.T) {
+		result, err := ReadMarkdown(strings.NewReader("# Notes\n\nSome text\n\n## In progress\n- [ ] Started\n* [x] Finished <!-- task:9 -->\n\n## Backend\n  - [ ] Tagged\n- [ ] \n"))
+
+		asserts.NoError(err)
+		asserts.Len(result.Tasks, 3)
+		asserts.Equal(models.IN_PROGRESS, result.Tasks[0].Status)
+		asserts.Equal(models.DONE, result.Tasks[1].Status)
+		asserts.Equal(9, result.Tasks[1].Id)
+		asserts.Equal("Tagged", result.Tasks[2].Description)
+		asserts.Equal([]string{"Backend"}, result.Tasks[2].Tags)
+		asserts.Equal([]string{"line 11 has no description and was skipped"}, result.Warnings)
+	})
+
+	t.Run("✅ Should sync a checklist with the store", func(t *testing.T) {
+		tasks := createTasks()
+		content := "# Sprint\n\n" +
+			"- [x] Task, number 1 <!-- task:1 -->\n" +
+			"- [ ] Task two renamed <!-- task:2 -->\n" +
+			"- [ ] Deleted <!-- task:7 -->\n" +
+			"- [ ] Brand new\n"
+
+		synced, changed, report := SyncMarkdown(content, now, tasks, nil, true, now)
+
+		asserts.Equal(SyncReport{Added: 1, UpdatedTasks: 2, Appended: 1, Removed: 1}, report)
+		asserts.Equal("# Sprint\n\n"+
+			"- [x] Task, number 1 <!-- task:1 -->\n"+
+			"- [ ] Task two renamed <!-- task:2 -->\n"+
+			"- [ ] Brand new <!-- task:4 -->\n"+
+			"- [x] Task, number 3 <!-- task:3 -->\n", synced)
+		asserts.Len(changed, 3)
+		asserts.Equal(models.DONE, tasks[0].Status)
+		asserts.Equal(models.IN_PROGRESS, tasks[1].Status)
+		asserts.Equal("Task two renamed", tasks[1].Description)
+		asserts.Equal(4, changed[2].Id)
+	})
+
+	t.Run("✅ Should keep the items of missing tasks unless pruning", func(t *testing.T) {
+		content := "- [ ] Task, number 1 <!-- task:1 -->\n" +
+			"- [ ] Elsewhere <!-- task:7 -->\n"
+
+		synced, changed, report := SyncMarkdown(content, now, []*models.Task{}, nil, false, now)
+
+		asserts.Equal(SyncReport{Missing: 2}, report)
+		asserts.Equal(content, synced)
+		asserts.Empty(changed)
+	})
+
+	t.Run("✅ Should not give a new item the ID of a kept missing task", func(t *testing.T) {
+		content := "- [ ] Task, number 1 <!-- task:1 -->\n" +
+			"- [ ] Elsewhere <!-- task:11 -->\n" +
+			"- [ ] Brand new\n"
+
+		synced, changed, report := SyncMarkdown(content, now, createTasks(), nil, false, now)
+
+		asserts.Equal(1, report.Added)
+		asserts.Equal(1, report.Missing)
+		asserts.Contains(synced, "- [ ] Elsewhere <!-- task:11 -->\n- [ ] Brand new <!-- task:12 -->\n")
+		asserts.Len(changed, 1)
+		asserts.Equal(12, changed[0].Id)
+	})
+
+	t.Run("✅ Should keep the store version when it changed last", func(t *testing.T) {
+		tasks := createTasks()
+		content := "- [x] Task, number 1 <!-- task:1 -->\n"
+
+		synced, changed, report := SyncMarkdown(content, now.AddDate(-1, 0, 0), tasks, func(task *models.Task) bool {
+			return task.Id == 1
+		}, false, now)
+
+		asserts.Equal(SyncReport{UpdatedLines: 1}, report)
+		asserts.Equal("- [ ] Task, number 1 <!-- task:1 -->\n", synced)
+		asserts.Empty(changed)
+	})
+}
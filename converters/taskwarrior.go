@@ -0,0 +1,308 @@
+package converters
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+const taskwarriorTime = "20060102T150405Z"
+
+type taskwarriorTask struct {
+	Uuid        string   `json:"uuid,omitempty"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Entry       string   `json:"entry,omitempty"`
+	Modified    string   `json:"modified,omitempty"`
+	Start       string   `json:"start,omitempty"`
+	End         string   `json:"end,omitempty"`
+	Due         string   `json:"due,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	Project     string   `json:"project,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Depends     uuids    `json:"depends,omitempty"`
+}
+
+// uuids reads depends as Taskwarrior 2 writes it, comma separated, or as
+// the array of Taskwarrior 3, and writes it comma separated.
+type uuids []string
+
+// taskwarriorMapped are the attributes ReadTaskwarrior converts, the
+// others are counted in its report.
+var taskwarriorMapped = []string{"id", "description", "status", "entry", "modified", "start", "end", "due", "priority", "project", "tags", "depends", "urgency"}
+
+// ReadTaskwarrior reads the JSON of `task export`, an array or one object
+// per line. Deleted tasks are skipped. Tasks are numbered in the order of
+// the export so that depends can point at the tasks it names, and the
+// result IDs only link the tasks of the batch. The warnings report what
+// could not be converted, such as uuid and annotations, with the number of
+// tasks that had it.
+func ReadTaskwarrior(r io.Reader) (*Result, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []map[string]json.RawMessage{}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &objects)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Taskwarrior export: %w", err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(nil, 1<<24)
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSuffix(strings.TrimSpace(scanner.Text()), ",")
+			if text == "" {
+				continue
+			}
+			object := map[string]json.RawMessage{}
+			if err := json.Unmarshal([]byte(text), &object); err != nil {
+				return nil, fmt.Errorf("invalid Taskwarrior export at line %d: %w", line, err)
+			}
+			objects = append(objects, object)
+		}
+	}
+
+	result := &Result{Tasks: []*models.Task{}, LocalIds: true}
+	unmapped := map[string]int{}
+	deleted, recurring, outside := 0, 0, 0
+	ids := map[string]int{}
+	depends := map[*models.Task]uuids{}
+
+	for i, object := range objects {
+		raw, _ := json.Marshal(object)
+		source := taskwarriorTask{}
+		if err := json.Unmarshal(raw, &source); err != nil {
+			return nil, fmt.Errorf("task %d: %w", i+1, err)
+		}
+
+		switch source.Status {
+		case "deleted":
+			deleted++
+			continue
+		case "recurring":
+			recurring++
+			continue
+		}
+
+		for key := range object {
+			if !slices.Contains(taskwarriorMapped, key) {
+				unmapped[key]++
+			}
+		}
+
+		task, err := fromTaskwarrior(source)
+		if err != nil {
+			return nil, fmt.Errorf("task %d: %w", i+1, err)
+		}
+		task.Id = len(result.Tasks) + 1
+		if source.Uuid != "" {
+			ids[source.Uuid] = task.Id
+		}
+		depends[task] = source.Depends
+		result.Tasks = append(result.Tasks, task)
+	}
+
+	for _, task := range result.Tasks {
+		missing := false
+		for _, uuid := range depends[task] {
+			id, ok := ids[uuid]
+			if !ok {
+				missing = true
+				continue
+			}
+			task.DependsOn = append(task.DependsOn, id)
+		}
+		if missing {
+			outside++
+		}
+	}
+
+	if deleted > 0 {
+		result.warn("%s deleted in Taskwarrior skipped", plural(deleted))
+	}
+	if recurring > 0 {
+		result.warn("%s with a recurrence template skipped, their pending instances were imported", plural(recurring))
+	}
+	if outside > 0 {
+		result.warn("depends of %s on tasks outside the export dropped", plural(outside))
+	}
+
+	keys := []string{}
+	for key := range unmapped {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result.warn("%s of %s could not be converted", key, plural(unmapped[key]))
+	}
+	return result, nil
+}
+
+// WriteTaskwarrior writes tasks as a JSON array `task import` accepts, with
+// a UUID derived from each task's ID and creation time. Dependencies on
+// tasks left out of the export are dropped.
+func WriteTaskwarrior(w io.Writer, tasks []*models.Task) error {
+	records := []taskwarriorTask{}
+	exported := map[int]*models.Task{}
+	for _, task := range tasks {
+		exported[task.Id] = task
+	}
+	format := func(date *time.Time) string {
+		if date == nil {
+			return ""
+		}
+		return date.UTC().Format(taskwarriorTime)
+	}
+
+	for _, task := range tasks {
+		record := taskwarriorTask{
+			Uuid:        taskwarriorUuid(task),
+			Description: task.Description,
+			Status:      "pending",
+			Entry:       format(&task.CreatedAt),
+			Modified:    format(task.UpdatedAt),
+			Due:         format(task.Due),
+			Priority:    taskwarriorPriority(task.Priority),
+			Tags:        task.Tags,
+			Project:     task.Project,
+		}
+		for _, id := range task.DependsOn {
+			if dependency, ok := exported[id]; ok {
+				record.Depends = append(record.Depends, taskwarriorUuid(dependency))
+			}
+		}
+
+		switch task.Status {
+		case models.DONE:
+			record.Status = "completed"
+			record.End = format(task.CompletedAt())
+			if record.End == "" {
+				record.End = record.Entry
+			}
+		case models.IN_PROGRESS:
+			record.Start = format(task.EnteredAt(models.IN_PROGRESS))
+			if record.Start == "" {
+				record.Start = record.Entry
+			}
+		}
+		records = append(records, record)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", " ")
+	return encoder.Encode(records)
+}
+
+func fromTaskwarrior(source taskwarriorTask) (*models.Task, error) {
+	task := &models.Task{Description: source.Description, Status: models.TODO, Tags: source.Tags, Project: source.Project}
+
+	parse := func(value string) (*time.Time, error) {
+		if value == "" {
+			return nil, nil
+		}
+		date, err := time.Parse(taskwarriorTime, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", value)
+		}
+		return &date, nil
+	}
+
+	dates := []*time.Time{}
+	for _, value := range []string{source.Entry, source.Modified, source.Start, source.End, source.Due} {
+		date, err := parse(value)
+		if err != nil {
+			return nil, err
+		}
+		dates = append(dates, date)
+	}
+
+	if dates[0] != nil {
+		task.CreatedAt = *dates[0]
+	}
+	task.UpdatedAt = dates[1]
+	task.Due = dates[4]
+
+	if dates[2] != nil {
+		task.Status = models.IN_PROGRESS
+		task.History = append(task.History, models.StatusChange{Status: models.IN_PROGRESS, At: *dates[2]})
+	}
+	if source.Status == "completed" {
+		task.Status = models.DONE
+		if dates[3] != nil {
+			task.History = append(task.History, models.StatusChange{Status: models.DONE, At: *dates[3]})
+		}
+	}
+
+	switch source.Priority {
+	case "H":
+		task.Priority = models.HIGH
+	case "M":
+		task.Priority = models.MEDIUM
+	case "L":
+		task.Priority = models.LOW
+	}
+	return task, nil
+}
+
+func (u *uuids) UnmarshalJSON(data []byte) error {
+	list := []string{}
+	if err := json.Unmarshal(data, &list); err == nil {
+		*u = list
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid depends %s", data)
+	}
+	*u = nil
+	for _, uuid := range strings.Split(value, ",") {
+		if uuid = strings.TrimSpace(uuid); uuid != "" {
+			*u = append(*u, uuid)
+		}
+	}
+	return nil
+}
+
+func (u uuids) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(u, ","))
+}
+
+func taskwarriorPriority(priority models.Priority) string {
+	switch priority {
+	case models.HIGH:
+		return "H"
+	case models.MEDIUM:
+		return "M"
+	case models.LOW:
+		return "L"
+	}
+	return ""
+}
+
+// taskwarriorUuid derives a stable version 5 style UUID so exporting the
+// same task twice updates it in Taskwarrior instead of duplicating it.
+func taskwarriorUuid(task *models.Task) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("task-tracker:%d:%d", task.Id, task.CreatedAt.Unix())))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func plural(count int) string {
+	if count == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", count)
+}
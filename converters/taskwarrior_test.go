@@ -0,0 +1,100 @@
+package converters
+
+import (
+	"bytes"
+	"strings"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskwarrior(t *testing.T) {
+	asserts := assert.New(t)
+
+	t.Run("✅ Should import a Taskwarrior export with a mapping report", func(t *testing.T) {
+		export := `[
+			{"id":1,"uuid":"a1","description":"Fix login","status":"pending","entry":"20240820T093000Z","start":"20240821T100000Z","project":"web","priority":"H","tags":["bug"],"urgency":9.1,"annotations":[{"entry":"20240821T100000Z","description":"see logs"}]},
+			{"id":0,"uuid":"a2","description":"Old","status":"completed","entry":"20240801T093000Z","end":"20240802T093000Z","due":"20240803T000000Z","depends":"a1"},
+			{"id":0,"uuid":"a3","description":"Gone","status":"deleted","entry":"20240801T093000Z"}
+		]`
+
+		result, err := ReadTaskwarrior(strings.NewReader(export))
+
+		asserts.NoError(err)
+		asserts.Len(result.Tasks, 2)
+		asserts.Equal(models.IN_PROGRESS, result.Tasks[0].Status)
+		asserts.Equal(models.HIGH, result.Tasks[0].Priority)
+		asserts.Equal([]string{"bug"}, result.Tasks[0].Tags)
+		asserts.Equal("web", result.Tasks[0].Project)
+		asserts.Equal(time.Date(2024, 8, 21, 10, 0, 0, 0, time.UTC), *result.Tasks[0].StartedAt())
+		asserts.Equal(models.DONE, result.Tasks[1].Status)
+		asserts.Equal(time.Date(2024, 8, 2, 9, 30, 0, 0, time.UTC), *result.Tasks[1].CompletedAt())
+		asserts.Equal(time.Date(2024, 8, 3, 0, 0, 0, 0, time.UTC), *result.Tasks[1].Due)
+		asserts.Equal([]int{1}, result.Tasks[1].DependsOn)
+		asserts.True(result.LocalIds)
+		asserts.Equal([]string{
+			"1 task deleted in Taskwarrior skipped",
+			"annotations of 1 task could not be converted",
+			"uuid of 2 tasks could not be converted",
+		}, result.Warnings)
+	})
+
+	t.Run("✅ Should read one task per line", func(t *testing.T) {
+		result, err := ReadTaskwarrior(strings.NewReader("{\"description\":\"One\",\"status\":\"pending\"},\n{\"description\":\"Two\",\"status\":\"waiting\"}\n"))
+
+		asserts.NoError(err)
+		asserts.Len(result.Tasks, 2)
+		asserts.Equal(models.TODO, result.Tasks[1].Status)
+	})
+
+	t.Run("✅ Should link the dependencies listed as an array", func(t *testing.T) {
+		export := `[
+			{"uuid":"a1","description":"Design","status":"pending"},
+			{"uuid":"a2","description":"Build","status":"pending","depends":["a1","b9"]},
+			{"uuid":"a3","description":"Ship","status":"pending","depends":["a1","a2"]}
+		]`
+
+		result, err := ReadTaskwarrior(strings.NewReader(export))
+
+		asserts.NoError(err)
+		asserts.Equal([]int{1}, result.Tasks[1].DependsOn)
+		asserts.Equal([]int{1, 2}, result.Tasks[2].DependsOn)
+		asserts.Contains(result.Warnings, "depends of 1 task on tasks outside the export dropped")
+	})
+
+	t.Run("✅ Should round trip tasks", func(t *testing.T) {
+		tasks := []*models.Task{createTask(1, models.DONE), createTask(2, models.TODO)}
+		tasks[1].Project = "web"
+		tasks[1].DependsOn = []int{1, 5}
+
+		var buffer bytes.Buffer
+		asserts.NoError(WriteTaskwarrior(&buffer, tasks))
+		asserts.Contains(buffer.String(), `"status": "completed"`)
+		asserts.Contains(buffer.String(), `"end": "20240821T100000Z"`)
+		asserts.Contains(buffer.String(), `"priority": "H"`)
+		asserts.Contains(buffer.String(), `"depends": "`+taskwarriorUuid(tasks[0])+`"`)
+
+		result, err := ReadTaskwarrior(&buffer)
+		asserts.NoError(err)
+		asserts.Equal([]string{"uuid of 2 tasks could not be converted"}, result.Warnings)
+		for i, task := range result.Tasks {
+			asserts.Equal(tasks[i].Description, task.Description)
+			asserts.Equal(tasks[i].Status, task.Status)
+			asserts.Equal(tasks[i].Priority, task.Priority)
+			asserts.Equal(tasks[i].Tags, task.Tags)
+			asserts.Equal(tasks[i].Project, task.Project)
+			asserts.True(tasks[i].Due.Equal(*task.Due))
+		}
+		asserts.Equal([]int{1}, result.Tasks[1].DependsOn)
+	})
+
+	t.Run("✅ Should derive stable UUIDs", func(t *testing.T) {
+		task := createTask(1, models.TODO)
+
+		asserts.Equal(taskwarriorUuid(task), taskwarriorUuid(task))
+		asserts.Regexp(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, taskwarriorUuid(task))
+		asserts.NotEqual(taskwarriorUuid(task), taskwarriorUuid(createTask(2, models.TODO)))
+	})
+}
@@ -0,0 +1,257 @@
+package converters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)$`)
+	todoTxtDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	todoTxtKeyValue = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):([^\s/][^\s]*)$`)
+)
+
+// ParseTodoTxt reads one todo.txt line. The first +project is the project
+// of the task and any later one a tag, contexts are tags starting with @,
+// and the id, due, status, pri, tag, assignee, depends and notes keys map
+// onto their task fields, notes being URL query escaped. Other keys stay in
+// the description and are returned as warnings.
+func ParseTodoTxt(line string) (*models.Task, []string, error) {
+	task := &models.Task{Status: models.TODO}
+	warnings := []string{}
+	words := strings.Fields(line)
+
+	if len(words) > 0 && words[0] == "x" {
+		task.Status = models.DONE
+		words = words[1:]
+		if len(words) > 0 && todoTxtDate.MatchString(words[0]) {
+			completed, err := time.ParseInLocation(time.DateOnly, words[0], time.Local)
+			if err != nil {
+				return nil, nil, err
+			}
+			task.History = append(task.History, models.StatusChange{Status: models.DONE, At: completed})
+			words = words[1:]
+		}
+	} else if len(words) > 0 && todoTxtPriority.MatchString(words[0]) {
+		task.Priority = todoTxtToPriority(words[0][1])
+		words = words[1:]
+	}
+
+	if len(words) > 0 && todoTxtDate.MatchString(words[0]) {
+		created, err := time.ParseInLocation(time.DateOnly, words[0], time.Local)
+		if err != nil {
+			return nil, nil, err
+		}
+		task.CreatedAt = created
+		words = words[1:]
+	}
+
+	description := []string{}
+	for _, word := range words {
+		switch {
+		case len(word) > 1 && word[0] == '+' && task.Project == "":
+			task.Project = strings.ReplaceAll(word[1:], "_", " ")
+		case len(word) > 1 && word[0] == '+':
+			task.Tags = append(task.Tags, word[1:])
+		case len(word) > 1 && word[0] == '@':
+			task.Tags = append(task.Tags, word)
+		case todoTxtKeyValue.MatchString(word):
+			match := todoTxtKeyValue.FindStringSubmatch(word)
+			known, err := setTodoTxtKey(task, match[1], match[2])
+			if err != nil {
+				return nil, nil, err
+			}
+			if !known {
+				warnings = append(warnings, fmt.Sprintf("key %s has no task field and was kept in the description", match[1]))
+				description = append(description, word)
+			}
+		default:
+			description = append(description, word)
+		}
+	}
+
+	task.Description = strings.Join(description, " ")
+	return task, warnings, nil
+}
+
+// FormatTodoTxt writes a task as one todo.txt line. The project is a
+// +project, tags starting with @ are contexts and the others tag keys. A
+// done task without a completion record is taken as completed when last
+// updated, since todo.txt reads a lone date after x as the completion date.
+func FormatTodoTxt(task *models.Task) string {
+	words := []string{}
+
+	if task.Status == models.DONE {
+		words = append(words, "x")
+		completed := task.CompletedAt()
+		if completed == nil && !task.CreatedAt.IsZero() {
+			completed = &task.CreatedAt
+			if task.UpdatedAt != nil {
+				completed = task.UpdatedAt
+			}
+		}
+		if completed != nil {
+			words = append(words, completed.Local().Format(time.DateOnly))
+		}
+	} else if priority := todoTxtFromPriority(task.Priority); priority != "" {
+		words = append(words, "("+priority+")")
+	}
+
+	if !task.CreatedAt.IsZero() {
+		words = append(words, task.CreatedAt.Local().Format(time.DateOnly))
+	}
+
+	words = append(words, task.Description)
+	if task.Project != "" {
+		words = append(words, "+"+strings.ReplaceAll(task.Project, " ", "_"))
+	}
+	for _, tag := range task.Tags {
+		if strings.HasPrefix(tag, "@") {
+			words = append(words, strings.ReplaceAll(tag, " ", "_"))
+			continue
+		}
+		words = append(words, "tag:"+strings.ReplaceAll(tag, " ", "_"))
+	}
+
+	if task.Due != nil {
+		words = append(words, "due:"+task.Due.Local().Format(time.DateOnly))
+	}
+	if task.Status == models.DONE && task.Priority != "" {
+		words = append(words, "pri:"+todoTxtFromPriority(task.Priority))
+	}
+	if task.Status == models.IN_PROGRESS {
+		words = append(words, "status:in-progress")
+	}
+	if task.Assignee != "" {
+		words = append(words, "assignee:"+strings.ReplaceAll(task.Assignee, " ", "_"))
+	}
+	if len(task.DependsOn) > 0 {
+		ids := []string{}
+		for _, id := range task.DependsOn {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		words = append(words, "depends:"+strings.Join(ids, ","))
+	}
+	if task.Notes != "" {
+		words = append(words, "notes:"+url.QueryEscape(task.Notes))
+	}
+	if task.Id > 0 {
+		words = append(words, "id:"+strconv.Itoa(task.Id))
+	}
+	return strings.Join(words, " ")
+}
+
+func ReadTodoTxt(r io.Reader) (*Result, error) {
+	result := &Result{Tasks: []*models.Task{}}
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		task, warnings, err := ParseTodoTxt(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		for _, warning := range warnings {
+			result.warn("line %d: %s", line, warning)
+		}
+
+		if task.Description == "" {
+			result.warn("line %d has no description and was skipped", line)
+			continue
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+	return result, scanner.Err()
+}
+
+func WriteTodoTxt(w io.Writer, tasks []*models.Task) error {
+	writer := bufio.NewWriter(w)
+	for _, task := range tasks {
+		writer.WriteString(FormatTodoTxt(task) + "\n")
+	}
+	return writer.Flush()
+}
+
+func setTodoTxtKey(task *models.Task, key string, value string) (bool, error) {
+	switch strings.ToLower(key) {
+	case "id":
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 0 {
+			return false, fmt.Errorf("invalid id %q", value)
+		}
+		task.Id = id
+	case "due":
+		due, err := parseTime(value, "")
+		if err != nil {
+			return false, err
+		}
+		task.Due = &due
+	case "status":
+		status, err := models.ParseStatus(value)
+		if err != nil {
+			return false, err
+		}
+		if task.Status != models.DONE {
+			task.Status = status
+		}
+	case "pri":
+		if len(value) == 1 && value[0] >= 'A' && value[0] <= 'Z' {
+			task.Priority = todoTxtToPriority(value[0])
+		}
+	case "tag":
+		task.Tags = append(task.Tags, strings.ReplaceAll(value, "_", " "))
+	case "project":
+		// Written before projects became +project words.
+		task.Project = strings.ReplaceAll(value, "_", " ")
+	case "assignee":
+		task.Assignee = strings.ReplaceAll(value, "_", " ")
+	case "depends":
+		dependsOn, err := models.ParseDependencies(value)
+		if err != nil {
+			return false, err
+		}
+		task.DependsOn = dependsOn
+	case "notes":
+		notes, err := url.QueryUnescape(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid notes %q", value)
+		}
+		task.Notes = notes
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// todoTxtToPriority maps A to high, B to medium and C and below to low.
+func todoTxtToPriority(letter byte) models.Priority {
+	switch letter {
+	case 'A':
+		return models.HIGH
+	case 'B':
+		return models.MEDIUM
+	}
+	return models.LOW
+}
+
+func todoTxtFromPriority(priority models.Priority) string {
+	switch priority {
+	case models.HIGH:
+		return "A"
+	case models.MEDIUM:
+		return "B"
+	case models.LOW:
+		return "C"
+	}
+	return ""
+}
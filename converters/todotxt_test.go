@@ -0,0 +1,99 @@
+package converters
+
+import (
+	"bytes"
+	"strings"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoTxt(t *testing.T) {
+	asserts := assert.New(t)
+
+	t.Run("✅ Should parse priorities, projects, contexts and keys", func(t *testing.T) {
+		task, warnings, err := ParseTodoTxt("(A) 2024-08-20 Call mom at 10:30 +family @phone due:2024-09-01 status:in-progress id:7 foo:bar")
+
+		asserts.NoError(err)
+		asserts.Equal(7, task.Id)
+		asserts.Equal("Call mom at 10:30 foo:bar", task.Description)
+		asserts.Equal(models.HIGH, task.Priority)
+		asserts.Equal(models.IN_PROGRESS, task.Status)
+		asserts.Equal("family", task.Project)
+		asserts.Equal([]string{"@phone"}, task.Tags)
+		asserts.Equal(time.Date(2024, 8, 20, 0, 0, 0, 0, time.Local), task.CreatedAt)
+		asserts.Equal(time.Date(2024, 9, 1, 0, 0, 0, 0, time.Local), *task.Due)
+		asserts.Equal([]string{"key foo has no task field and was kept in the description"}, warnings)
+	})
+
+	t.Run("✅ Should parse completed tasks", func(t *testing.T) {
+		task, _, err := ParseTodoTxt("x 2024-08-22 2024-08-20 Ship release pri:B")
+
+		asserts.NoError(err)
+		asserts.Equal(models.DONE, task.Status)
+		asserts.Equal(models.MEDIUM, task.Priority)
+		asserts.Equal(time.Date(2024, 8, 22, 0, 0, 0, 0, time.Local), *task.CompletedAt())
+	})
+
+	t.Run("✅ Should keep later projects as tags", func(t *testing.T) {
+		task, warnings, err := ParseTodoTxt("Plan trip +Summer_holidays +travel tag:family")
+
+		asserts.NoError(err)
+		asserts.Empty(warnings)
+		asserts.Equal("Plan trip", task.Description)
+		asserts.Equal("Summer holidays", task.Project)
+		asserts.Equal([]string{"travel", "family"}, task.Tags)
+	})
+
+	t.Run("✅ Should write a completion date before the creation date of done tasks", func(t *testing.T) {
+		task := createTask(1, models.DONE)
+		task.Tags, task.Due, task.Priority, task.History = nil, nil, "", nil
+
+		asserts.Equal("x 2024-08-20 2024-08-20 Task, number 1 id:1", FormatTodoTxt(task))
+
+		updated := time.Date(2024, 8, 23, 12, 0, 0, 0, time.Local)
+		task.UpdatedAt = &updated
+		parsed, _, err := ParseTodoTxt(FormatTodoTxt(task))
+
+		asserts.NoError(err)
+		asserts.Equal(time.Date(2024, 8, 20, 0, 0, 0, 0, time.Local), parsed.CreatedAt)
+		asserts.Equal(time.Date(2024, 8, 23, 0, 0, 0, 0, time.Local), *parsed.CompletedAt())
+	})
+
+	t.Run("✅ Should round trip tasks", func(t *testing.T) {
+		tasks := []*models.Task{createTask(1, models.DONE), createTask(2, models.IN_PROGRESS)}
+		tasks[1].Tags = []string{"backend", "@office"}
+		tasks[1].Project, tasks[1].Assignee = "Mobile app", "ana"
+		tasks[1].DependsOn = []int{1}
+		tasks[1].Notes = "Ask ops/QA: 50% done"
+
+		var buffer bytes.Buffer
+		asserts.NoError(WriteTodoTxt(&buffer, tasks))
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		asserts.Equal("x 2024-08-21 2024-08-20 Task, number 1 tag:backend tag:api due:2024-09-01 pri:A id:1", lines[0])
+		asserts.Equal("(A) 2024-08-20 Task, number 2 +Mobile_app tag:backend @office due:2024-09-01 status:in-progress assignee:ana depends:1 notes:Ask+ops%2FQA%3A+50%25+done id:2", lines[1])
+
+		result, err := ReadTodoTxt(&buffer)
+		asserts.NoError(err)
+		asserts.Empty(result.Warnings)
+		for i, task := range result.Tasks {
+			asserts.Equal(tasks[i].Id, task.Id)
+			asserts.Equal(tasks[i].Description, task.Description)
+			asserts.Equal(tasks[i].Status, task.Status)
+			asserts.Equal(tasks[i].Priority, task.Priority)
+			asserts.Equal(tasks[i].Tags, task.Tags)
+			asserts.Equal(tasks[i].Project, task.Project)
+			asserts.Equal(tasks[i].Assignee, task.Assignee)
+			asserts.Equal(tasks[i].DependsOn, task.DependsOn)
+			asserts.Equal(tasks[i].Notes, task.Notes)
+		}
+	})
+
+	t.Run("❌ Should report invalid keys with their line", func(t *testing.T) {
+		_, err := ReadTodoTxt(strings.NewReader("First\nSecond due:someday\n"))
+
+		asserts.EqualError(err, `line 2: invalid date "someday"`)
+	})
+}
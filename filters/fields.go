@@ -0,0 +1,176 @@
+package filters
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+type field struct {
+	ordered bool
+	parse   func(string) (any, error)
+	compare func(*models.Task, any) (int, bool)
+}
+
+var now = time.Now
+
+var relativeDate = regexp.MustCompile(`^([+-]?\d+)([dw])$`)
+
+var fields = map[string]field{
+	"id": {
+		ordered: true,
+		parse: func(value string) (any, error) {
+			return strconv.Atoi(value)
+		},
+		compare: func(task *models.Task, value any) (int, bool) {
+			return cmp.Compare(task.Id, value.(int)), true
+		},
+	},
+	"status": {
+		parse: func(value string) (any, error) {
+			return models.ParseStatus(value)
+		},
+		compare: func(task *models.Task, value any) (int, bool) {
+			return strings.Compare(string(task.Status), string(value.(models.Status))), true
+		},
+	},
+	"priority": {
+		ordered: true,
+		parse: func(value string) (any, error) {
+			if strings.EqualFold(value, "none") {
+				return 0, nil
+			}
+			priority, err := models.ParsePriority(value)
+			if err != nil {
+				return nil, err
+			}
+			return priority.Rank(), nil
+		},
+		compare: func(task *models.Task, value any) (int, bool) {
+			return cmp.Compare(task.Priority.Rank(), value.(int)), true
+		},
+	},
+	"tag": {
+		parse: func(value string) (any, error) {
+			return value, nil
+		},
+		compare: func(task *models.Task, value any) (int, bool) {
+			if task.HasTag(value.(string)) {
+				return 0, true
+			}
+			return 1, true
+		},
+	},
+	"project":  textField(func(task *models.Task) string { return task.Project }),
+	"assignee": textField(func(task *models.Task) string { return task.Assignee }),
+	"description": {
+		parse: func(value string) (any, error) {
+			return strings.ToLower(value), nil
+		},
+		compare: func(task *models.Task, value any) (int, bool) {
+			if strings.Contains(strings.ToLower(task.Description), value.(string)) {
+				return 0, true
+			}
+			return 1, true
+		},
+	},
+	"created": dateField(func(task *models.Task) *time.Time {
+		return &task.CreatedAt
+	}),
+	"updated": dateField(func(task *models.Task) *time.Time {
+		updated := lastUpdate(task)
+		return &updated
+	}),
+	"due": dateField(func(task *models.Task) *time.Time {
+		return task.Due
+	}),
+}
+
+var aliases = map[string]string{
+	"tags": "tag",
+	"desc": "description",
+}
+
+func lookupField(name string) (string, field, bool) {
+	name = strings.ToLower(name)
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	f, ok := fields[name]
+	return name, f, ok
+}
+
+// textField matches a text field of the task case-insensitively, "none"
+// matching tasks without one.
+func textField(get func(*models.Task) string) field {
+	return field{
+		parse: func(value string) (any, error) {
+			if strings.EqualFold(value, "none") {
+				return "", nil
+			}
+			return value, nil
+		},
+		compare: func(task *models.Task, value any) (int, bool) {
+			if strings.EqualFold(get(task), value.(string)) {
+				return 0, true
+			}
+			return 1, true
+		},
+	}
+}
+
+// dateField compares tasks by calendar day in the local time zone, so
+// "due<2024-09-01" holds for anything due before that day starts.
+func dateField(get func(*models.Task) *time.Time) field {
+	return field{
+		ordered: true,
+		parse: func(value string) (any, error) {
+			day, err := ParseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			return day.Format(time.DateOnly), nil
+		},
+		compare: func(task *models.Task, value any) (int, bool) {
+			date := get(task)
+			if date == nil {
+				return 0, false
+			}
+			return strings.Compare(date.Local().Format(time.DateOnly), value.(string)), true
+		},
+	}
+}
+
+// ParseDate accepts YYYY-MM-DD dates, today/yesterday/tomorrow and
+// offsets from today such as -14d or +2w.
+func ParseDate(value string) (time.Time, error) {
+	today := now().Local()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+
+	switch strings.ToLower(value) {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if match := relativeDate.FindStringSubmatch(strings.ToLower(value)); match != nil {
+		amount, _ := strconv.Atoi(match[1])
+		if match[2] == "w" {
+			amount *= 7
+		}
+		return today.AddDate(0, 0, amount), nil
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD, today, yesterday, tomorrow or an offset like -7d", value)
+	}
+	return date, nil
+}
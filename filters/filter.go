@@ -0,0 +1,249 @@
+package filters
+
+import (
+	"slices"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+type Operator string
+
+const (
+	HAS           Operator = Operator(":")
+	EQUAL         Operator = Operator("=")
+	NOT_EQUAL     Operator = Operator("!=")
+	LESS          Operator = Operator("<")
+	LESS_EQUAL    Operator = Operator("<=")
+	GREATER       Operator = Operator(">")
+	GREATER_EQUAL Operator = Operator(">=")
+)
+
+// Node is an element of a parsed filter query, evaluated against a single task.
+type Node interface {
+	Match(*models.Task) bool
+	String() string
+}
+
+type (
+	And struct {
+		Nodes []Node
+	}
+
+	Or struct {
+		Nodes []Node
+	}
+
+	Not struct {
+		Node Node
+	}
+
+	Comparison struct {
+		Field    string
+		Operator Operator
+		Values   []string
+		field    field
+		parsed   []any
+	}
+
+	Text struct {
+		Value string
+	}
+)
+
+func (a *And) Match(task *models.Task) bool {
+	for _, node := range a.Nodes {
+		if !node.Match(task) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *And) String() string {
+	return joinNodes(a.Nodes, " ")
+}
+
+func (o *Or) Match(task *models.Task) bool {
+	for _, node := range o.Nodes {
+		if node.Match(task) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Or) String() string {
+	return "(" + joinNodes(o.Nodes, " or ") + ")"
+}
+
+func (n *Not) Match(task *models.Task) bool {
+	return !n.Node.Match(task)
+}
+
+func (n *Not) String() string {
+	return "-" + n.Node.String()
+}
+
+func (c *Comparison) Match(task *models.Task) bool {
+	if c.Operator == NOT_EQUAL {
+		for _, value := range c.parsed {
+			result, ok := c.field.compare(task, value)
+			if !ok || result == 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, value := range c.parsed {
+		result, ok := c.field.compare(task, value)
+		if ok && c.Operator.holds(result) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Comparison) String() string {
+	return c.Field + string(c.Operator) + quote(strings.Join(c.Values, ","))
+}
+
+func (t *Text) Match(task *models.Task) bool {
+	return strings.Contains(strings.ToLower(task.Description), strings.ToLower(t.Value))
+}
+
+func (t *Text) String() string {
+	return quote(t.Value)
+}
+
+// Filter adapts a node to the filter function used by models.Query.
+func Filter(node Node) func(*models.Task) bool {
+	if node == nil {
+		return nil
+	}
+	return node.Match
+}
+
+// Where collects the predicates of node that a store can answer from its
+// indexes: the values of the first status, tag and project comparison and
+// the bounds of due dates. Only comparisons joined by and count, the ones
+// under or and not are left to the filter.
+func Where(node Node) models.Where {
+	where := models.Where{}
+	narrow(node, &where)
+	return where
+}
+
+func narrow(node Node, where *models.Where) {
+	switch node := node.(type) {
+	case *And:
+		for _, child := range node.Nodes {
+			narrow(child, where)
+		}
+	case *Comparison:
+		node.narrow(where)
+	}
+}
+
+func (c *Comparison) narrow(where *models.Where) {
+	if c.Field == "due" {
+		c.narrowDue(where)
+		return
+	}
+
+	if c.Operator != HAS && c.Operator != EQUAL {
+		return
+	}
+
+	switch {
+	case c.Field == "status" && where.Statuses == nil:
+		for _, value := range c.parsed {
+			where.Statuses = append(where.Statuses, value.(models.Status))
+		}
+	case c.Field == "tag" && where.Tags == nil:
+		for _, value := range c.parsed {
+			where.Tags = append(where.Tags, value.(string))
+		}
+	case c.Field == "project" && where.Projects == nil:
+		for _, value := range c.parsed {
+			where.Projects = append(where.Projects, value.(string))
+		}
+	}
+}
+
+// narrowDue turns a due date comparison into bounds, as tasks without a due
+// date never match one.
+func (c *Comparison) narrowDue(where *models.Where) {
+	days := []time.Time{}
+	for _, value := range c.parsed {
+		day, err := time.ParseInLocation(time.DateOnly, value.(string), time.Local)
+		if err != nil {
+			return
+		}
+		days = append(days, day)
+	}
+	first, last := slices.MinFunc(days, time.Time.Compare), slices.MaxFunc(days, time.Time.Compare)
+
+	from := func(day time.Time) {
+		if where.DueFrom == nil || day.After(*where.DueFrom) {
+			where.DueFrom = &day
+		}
+	}
+	before := func(day time.Time) {
+		if where.DueBefore == nil || day.Before(*where.DueBefore) {
+			where.DueBefore = &day
+		}
+	}
+
+	switch c.Operator {
+	case HAS, EQUAL:
+		from(first)
+		before(last.AddDate(0, 0, 1))
+	case LESS:
+		before(first)
+	case LESS_EQUAL:
+		before(first.AddDate(0, 0, 1))
+	case GREATER:
+		from(first.AddDate(0, 0, 1))
+	case GREATER_EQUAL:
+		from(first)
+	}
+}
+
+func (o Operator) holds(result int) bool {
+	switch o {
+	case HAS, EQUAL:
+		return result == 0
+	case NOT_EQUAL:
+		return result != 0
+	case LESS:
+		return result < 0
+	case LESS_EQUAL:
+		return result <= 0
+	case GREATER:
+		return result > 0
+	case GREATER_EQUAL:
+		return result >= 0
+	}
+	return false
+}
+
+func (o Operator) ordered() bool {
+	return o == LESS || o == LESS_EQUAL || o == GREATER || o == GREATER_EQUAL
+}
+
+func joinNodes(nodes []Node, separator string) string {
+	parts := []string{}
+	for _, node := range nodes {
+		parts = append(parts, node.String())
+	}
+	return strings.Join(parts, separator)
+}
+
+func quote(value string) string {
+	if strings.ContainsAny(value, " \t()") {
+		return `"` + value + `"`
+	}
+	return value
+}
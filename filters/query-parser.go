@@ -0,0 +1,292 @@
+package filters
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	textToken
+	notToken
+	orToken
+	openToken
+	closeToken
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+type parser struct {
+	tokens   []token
+	position int
+}
+
+var operators = []Operator{GREATER_EQUAL, LESS_EQUAL, NOT_EQUAL, HAS, EQUAL, GREATER, LESS}
+
+// Parse turns a query such as
+//
+//	status:todo,in-progress priority>=high tag:backend due<2024-09-01 "text"
+//
+// into a filter tree. Terms are joined with AND, "or" binds looser than
+// AND, parentheses group terms and a leading "-" negates a term. An empty
+// query matches every task.
+func Parse(query string) (Node, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return &And{}, nil
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.position < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in query", p.tokens[p.position].value)
+	}
+	return node, nil
+}
+
+// JoinArgs rebuilds a query from command line arguments, quoting the ones
+// the shell already unquoted so phrases stay together. A single argument is
+// taken as the whole query.
+func JoinArgs(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	parts := []string{}
+	for _, arg := range args {
+		if strings.ContainsAny(arg, " \t") && !strings.Contains(arg, `"`) {
+			arg = `"` + arg + `"`
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (p *parser) parseOr() (Node, error) {
+	nodes := []Node{}
+
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+
+		if !p.accept(orToken) {
+			break
+		}
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &Or{Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	nodes := []Node{}
+
+	for p.position < len(p.tokens) {
+		kind := p.tokens[p.position].kind
+		if kind == orToken || kind == closeToken {
+			break
+		}
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("missing term in query")
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &And{Nodes: nodes}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.accept(notToken) {
+		if p.position >= len(p.tokens) {
+			return nil, fmt.Errorf("missing term after \"-\" in query")
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Node: node}, nil
+	}
+
+	current := p.tokens[p.position]
+	p.position++
+
+	switch current.kind {
+	case openToken:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(closeToken) {
+			return nil, fmt.Errorf("missing \")\" in query")
+		}
+		return node, nil
+	case textToken:
+		return &Text{Value: current.value}, nil
+	case wordToken:
+		return parseTerm(current.value)
+	}
+	return nil, fmt.Errorf("unexpected %q in query", current.value)
+}
+
+func (p *parser) accept(kind tokenKind) bool {
+	if p.position < len(p.tokens) && p.tokens[p.position].kind == kind {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func parseTerm(term string) (Node, error) {
+	name, operator, value, ok := splitTerm(term)
+	if !ok {
+		return &Text{Value: term}, nil
+	}
+
+	fieldName, f, ok := lookupField(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %q", name)
+	}
+
+	if operator.ordered() && !f.ordered {
+		return nil, fmt.Errorf("field %q does not support %q", fieldName, operator)
+	}
+
+	if value == "" {
+		return nil, fmt.Errorf("missing value for field %q", fieldName)
+	}
+
+	values := []string{value}
+	if !operator.ordered() {
+		values = strings.Split(value, ",")
+	}
+
+	comparison := &Comparison{Field: fieldName, Operator: operator, Values: values, field: f}
+	for _, v := range values {
+		parsed, err := f.parse(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		comparison.parsed = append(comparison.parsed, parsed)
+	}
+	return comparison, nil
+}
+
+func splitTerm(term string) (string, Operator, string, bool) {
+	end := 0
+	for end < len(term) && (unicode.IsLetter(rune(term[end])) || term[end] == '_') {
+		end++
+	}
+	if end == 0 {
+		return "", "", "", false
+	}
+
+	for _, operator := range operators {
+		if strings.HasPrefix(term[end:], string(operator)) {
+			return term[:end], operator, term[end+len(operator):], true
+		}
+	}
+	return "", "", "", false
+}
+
+func tokenize(query string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: openToken, value: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: closeToken, value: ")"})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, token{kind: notToken, value: "-"})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote in query")
+			}
+			tokens = append(tokens, token{kind: textToken, value: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			word := []rune{}
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				if runes[i] == '"' {
+					end := i + 1
+					for end < len(runes) && runes[end] != '"' {
+						end++
+					}
+					if end >= len(runes) {
+						return nil, fmt.Errorf("unterminated quote in query")
+					}
+					word = append(word, runes[i+1:end]...)
+					i = end + 1
+					continue
+				}
+				word = append(word, runes[i])
+				i++
+			}
+
+			value := string(word)
+			switch strings.ToLower(value) {
+			case "or":
+				tokens = append(tokens, token{kind: orToken, value: value})
+			case "and":
+			default:
+				tokens = append(tokens, token{kind: wordToken, value: value})
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// Equals builds the comparison name=value without parsing a query, so the
+// value is taken as a whole however it is quoted or punctuated.
+func Equals(name string, value string) (*Comparison, error) {
+	fieldName, f, ok := lookupField(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %q", name)
+	}
+
+	parsed, err := f.parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{Field: fieldName, Operator: EQUAL, Values: []string{value}, field: f, parsed: []any{parsed}}, nil
+}
@@ -0,0 +1,189 @@
+package filters
+
+import (
+	"fmt"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryParser(t *testing.T) {
+	asserts := assert.New(t)
+	now = func() time.Time { return time.Date(2024, 8, 25, 12, 0, 0, 0, time.Local) }
+
+	t.Run("✅ Should match every task with an empty query", func(t *testing.T) {
+		node, err := Parse("")
+
+		asserts.Nil(err)
+		asserts.True(node.Match(createTask(1, models.TODO)))
+	})
+
+	t.Run("✅ Should match any of the listed statuses", func(t *testing.T) {
+		node, err := Parse("status:todo,in-progress")
+
+		asserts.Nil(err)
+		asserts.True(node.Match(createTask(1, models.TODO)))
+		asserts.True(node.Match(createTask(2, models.IN_PROGRESS)))
+		asserts.False(node.Match(createTask(3, models.DONE)))
+	})
+
+	t.Run("✅ Should compare priorities by rank", func(t *testing.T) {
+		node, err := Parse("priority>=medium")
+		low := createTask(1, models.TODO)
+		low.Priority = models.LOW
+		high := createTask(2, models.TODO)
+		high.Priority = models.HIGH
+
+		asserts.Nil(err)
+		asserts.False(node.Match(low))
+		asserts.True(node.Match(high))
+		asserts.False(node.Match(createTask(3, models.TODO)))
+	})
+
+	t.Run("✅ Should compare dates by day and skip tasks without a due date", func(t *testing.T) {
+		node, err := Parse("due<2024-09-01")
+		due := time.Date(2024, 8, 31, 23, 0, 0, 0, time.Local)
+		task := createTask(1, models.TODO)
+		task.Due = &due
+
+		asserts.Nil(err)
+		asserts.True(node.Match(task))
+		asserts.False(node.Match(createTask(2, models.TODO)))
+	})
+
+	t.Run("✅ Should resolve relative dates from today", func(t *testing.T) {
+		node, err := Parse("created>=-1d")
+
+		asserts.Nil(err)
+		asserts.True(node.Match(&models.Task{CreatedAt: time.Date(2024, 8, 24, 8, 0, 0, 0, time.Local)}))
+		asserts.False(node.Match(&models.Task{CreatedAt: time.Date(2024, 8, 23, 8, 0, 0, 0, time.Local)}))
+	})
+
+	t.Run("✅ Should count status changes as updates", func(t *testing.T) {
+		node, err := Parse("updated<-14d")
+		stale := &models.Task{CreatedAt: time.Date(2024, 8, 1, 8, 0, 0, 0, time.Local)}
+		moved := &models.Task{CreatedAt: stale.CreatedAt, History: []models.StatusChange{
+			{Status: models.IN_PROGRESS, At: time.Date(2024, 8, 24, 8, 0, 0, 0, time.Local)},
+		}}
+
+		asserts.Nil(err)
+		asserts.True(node.Match(stale))
+		asserts.False(node.Match(moved))
+	})
+
+	t.Run("✅ Should match projects and assignees in any case", func(t *testing.T) {
+		node, err := Parse("project:web assignee:none")
+		task := createTask(1, models.TODO)
+		task.Project = "Web"
+		assigned := createTask(2, models.TODO)
+		assigned.Project, assigned.Assignee = "web", "ana"
+
+		asserts.Nil(err)
+		asserts.True(node.Match(task))
+		asserts.False(node.Match(assigned))
+		asserts.False(node.Match(createTask(3, models.TODO)))
+	})
+
+	t.Run("✅ Should combine tags, text, negation and or", func(t *testing.T) {
+		node, err := Parse(`(tag:backend or tag:api) -status:done "Task 1"`)
+		task := createTask(1, models.TODO)
+		task.Tags = []string{"Backend"}
+		done := createTask(1, models.DONE)
+		done.Tags = []string{"api"}
+
+		asserts.Nil(err)
+		asserts.True(node.Match(task))
+		asserts.False(node.Match(done))
+		asserts.False(node.Match(createTask(1, models.TODO)))
+	})
+
+	t.Run("✅ Should collect the predicates stores can answer from indexes", func(t *testing.T) {
+		node, err := Parse(`status:todo,in-progress tag:api project:none due>=2024-09-01 due<2024-09-10 (tag:web or priority:high) -project:web`)
+		asserts.Nil(err)
+
+		from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.Local)
+		before := time.Date(2024, 9, 10, 0, 0, 0, 0, time.Local)
+		asserts.Equal(models.Where{
+			Statuses:  []models.Status{models.TODO, models.IN_PROGRESS},
+			Tags:      []string{"api"},
+			Projects:  []string{""},
+			DueFrom:   &from,
+			DueBefore: &before,
+		}, Where(node))
+
+		node, err = Parse(`due:tomorrow "buy milk"`)
+		asserts.Nil(err)
+		tomorrow, after := time.Date(2024, 8, 26, 0, 0, 0, 0, time.Local), time.Date(2024, 8, 27, 0, 0, 0, 0, time.Local)
+		asserts.Equal(models.Where{DueFrom: &tomorrow, DueBefore: &after}, Where(node))
+
+		node, err = Parse(`status:done or tag:api`)
+		asserts.Nil(err)
+		asserts.True(Where(node).IsZero())
+	})
+
+	t.Run("✅ Should print the query back", func(t *testing.T) {
+		node, err := Parse(`status:todo,done -tag:api "buy milk"`)
+
+		asserts.Nil(err)
+		asserts.Equal(`status:todo,done -tag:api "buy milk"`, node.String())
+	})
+
+	t.Run("✅ Should keep phrases together when joining arguments", func(t *testing.T) {
+		asserts.Equal(`status:todo "buy milk"`, JoinArgs([]string{"status:todo", "buy milk"}))
+	})
+
+	t.Run("✅ Should compare a whole value without parsing it as a query", func(t *testing.T) {
+		node, err := Equals("project", `Say "hi", or not`)
+		quoted := createTask(1, models.TODO)
+		quoted.Project = `say "hi", or not`
+		other := createTask(2, models.TODO)
+		other.Project = "not"
+
+		asserts.Nil(err)
+		asserts.True(node.Match(quoted))
+		asserts.False(node.Match(other))
+		asserts.Equal([]string{`Say "hi", or not`}, Where(node).Projects)
+	})
+
+	t.Run("❌ Should return an error for an unknown field", func(t *testing.T) {
+		node, err := Parse("stauts:todo")
+
+		asserts.Nil(node)
+		asserts.EqualError(err, `unknown filter field "stauts"`)
+	})
+
+	t.Run("❌ Should return an error for an invalid value", func(t *testing.T) {
+		_, err := Parse("status:later")
+
+		asserts.EqualError(err, `invalid status "later", expected: todo, in-progress, done`)
+	})
+
+	t.Run("❌ Should return an error when ordering an unordered field", func(t *testing.T) {
+		_, err := Parse("tag>backend")
+
+		asserts.EqualError(err, `field "tag" does not support ">"`)
+	})
+
+	t.Run("❌ Should return an error for unbalanced parentheses", func(t *testing.T) {
+		_, err := Parse("(status:todo")
+
+		asserts.EqualError(err, `missing ")" in query`)
+	})
+
+	t.Run("❌ Should return an error for a dangling or", func(t *testing.T) {
+		_, err := Parse("status:todo or")
+
+		asserts.EqualError(err, "missing term in query")
+	})
+}
+
+func createTask(id int, status models.Status) *models.Task {
+	return &models.Task{
+		Id:          id,
+		Description: fmt.Sprintf("Task %d", id),
+		Status:      status,
+		CreatedAt:   time.Date(2024, 8, 24, 0, 0, 0, 0, time.UTC),
+	}
+}
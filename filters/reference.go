@@ -0,0 +1,59 @@
+package filters
+
+import (
+	"strconv"
+	"strings"
+	"task-tracker/models"
+)
+
+// MatchReference finds the tasks a user reference can point to. References
+// are tried as a numeric ID, then as the whole description, a description
+// prefix, a substring and finally as a fuzzy subsequence of the description.
+// The first kind of match that finds anything wins.
+func MatchReference(tasks []*models.Task, reference string) []*models.Task {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return []*models.Task{}
+	}
+
+	if id, err := strconv.Atoi(reference); err == nil {
+		return matchTasks(tasks, func(task *models.Task) bool {
+			return task.Id == id
+		})
+	}
+
+	lower := strings.ToLower(reference)
+	matchers := []func(string) bool{
+		func(description string) bool { return description == lower },
+		func(description string) bool { return strings.HasPrefix(description, lower) },
+		func(description string) bool { return strings.Contains(description, lower) },
+		func(description string) bool { return isSubsequence(strings.ReplaceAll(lower, " ", ""), description) },
+	}
+
+	for _, matcher := range matchers {
+		found := matchTasks(tasks, func(task *models.Task) bool {
+			return matcher(strings.ToLower(task.Description))
+		})
+		if len(found) > 0 {
+			return found
+		}
+	}
+	return []*models.Task{}
+}
+
+func matchTasks(tasks []*models.Task, match func(*models.Task) bool) []*models.Task {
+	return models.Query{Filter: match}.Apply(tasks)
+}
+
+func isSubsequence(needle string, haystack string) bool {
+	remaining := []rune(needle)
+	for _, r := range haystack {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
@@ -0,0 +1,56 @@
+package filters
+
+import (
+	"task-tracker/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchReference(t *testing.T) {
+	asserts := assert.New(t)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{
+			createTask(1, models.TODO),
+			createTask(2, models.TODO),
+			createTask(3, models.TODO),
+			createTask(12, models.TODO),
+		}
+		tasks[0].Description = "Buy groceries"
+		tasks[1].Description = "Buy groceries and cook dinner"
+		tasks[2].Description = "Write the release notes"
+		tasks[3].Description = "Call 1"
+		return tasks
+	}
+
+	ids := func(tasks []*models.Task) []int {
+		result := []int{}
+		for _, task := range tasks {
+			result = append(result, task.Id)
+		}
+		return result
+	}
+
+	t.Run("✅ Should match a numeric reference by ID only", func(t *testing.T) {
+		asserts.Equal([]int{12}, ids(MatchReference(createTasks(), "12")))
+		asserts.Empty(MatchReference(createTasks(), "7"))
+	})
+
+	t.Run("✅ Should prefer a whole description over a prefix", func(t *testing.T) {
+		asserts.Equal([]int{1}, ids(MatchReference(createTasks(), "buy GROCERIES")))
+	})
+
+	t.Run("✅ Should return every task sharing a prefix", func(t *testing.T) {
+		asserts.Equal([]int{1, 2}, ids(MatchReference(createTasks(), "buy")))
+	})
+
+	t.Run("✅ Should fall back to substrings and fuzzy matches", func(t *testing.T) {
+		asserts.Equal([]int{2}, ids(MatchReference(createTasks(), "cook")))
+		asserts.Equal([]int{3}, ids(MatchReference(createTasks(), "rel nts")))
+	})
+
+	t.Run("❌ Should not match an empty reference", func(t *testing.T) {
+		asserts.Empty(MatchReference(createTasks(), " "))
+	})
+}
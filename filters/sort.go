@@ -0,0 +1,124 @@
+package filters
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+type sortKey func(a, b *models.Task) int
+
+var sortKeys = map[string]sortKey{
+	"id": func(a, b *models.Task) int {
+		return cmp.Compare(a.Id, b.Id)
+	},
+	"description": func(a, b *models.Task) int {
+		return cmp.Compare(strings.ToLower(a.Description), strings.ToLower(b.Description))
+	},
+	"status": func(a, b *models.Task) int {
+		return cmp.Compare(a.Status.Rank(), b.Status.Rank())
+	},
+	"priority": func(a, b *models.Task) int {
+		return cmp.Compare(a.Priority.Rank(), b.Priority.Rank())
+	},
+	"tag": func(a, b *models.Task) int {
+		return cmp.Compare(strings.ToLower(strings.Join(a.Tags, ",")), strings.ToLower(strings.Join(b.Tags, ",")))
+	},
+	"project": func(a, b *models.Task) int {
+		return cmp.Compare(strings.ToLower(a.Project), strings.ToLower(b.Project))
+	},
+	"assignee": func(a, b *models.Task) int {
+		return cmp.Compare(strings.ToLower(a.Assignee), strings.ToLower(b.Assignee))
+	},
+	"created": func(a, b *models.Task) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	},
+	"updated": func(a, b *models.Task) int {
+		return lastUpdate(a).Compare(lastUpdate(b))
+	},
+	"due": func(a, b *models.Task) int {
+		return a.Due.Compare(*b.Due)
+	},
+}
+
+// ParseSort builds a comparison from comma separated keys such as
+// "created,-priority,due". A leading "-" sorts that key descending and
+// tasks without a due date always sort last.
+func ParseSort(spec string) (func(a, b *models.Task) int, error) {
+	keys := []sortKey{}
+
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		descending := strings.HasPrefix(name, "-")
+		name = strings.ToLower(strings.TrimLeft(name, "-+"))
+		if alias, ok := aliases[name]; ok {
+			name = alias
+		}
+
+		key, ok := sortKeys[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q", name)
+		}
+
+		if descending {
+			key = reverse(key)
+		}
+		if name == "due" {
+			key = dueLast(key)
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	return func(a, b *models.Task) int {
+		for _, key := range keys {
+			if result := key(a, b); result != 0 {
+				return result
+			}
+		}
+		return 0
+	}, nil
+}
+
+func reverse(key sortKey) sortKey {
+	return func(a, b *models.Task) int {
+		return key(b, a)
+	}
+}
+
+func dueLast(key sortKey) sortKey {
+	return func(a, b *models.Task) int {
+		switch {
+		case a.Due == nil && b.Due == nil:
+			return 0
+		case a.Due == nil:
+			return 1
+		case b.Due == nil:
+			return -1
+		}
+		return key(a, b)
+	}
+}
+
+// lastUpdate is the latest of the creation, the last edit and the last
+// status change of the task, for tasks saved before status changes set
+// UpdatedAt.
+func lastUpdate(task *models.Task) time.Time {
+	updated := task.CreatedAt
+	if task.UpdatedAt != nil && task.UpdatedAt.After(updated) {
+		updated = *task.UpdatedAt
+	}
+	if count := len(task.History); count > 0 && task.History[count-1].At.After(updated) {
+		updated = task.History[count-1].At
+	}
+	return updated
+}
@@ -0,0 +1,65 @@
+package filters
+
+import (
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSort(t *testing.T) {
+	asserts := assert.New(t)
+
+	ids := func(tasks []*models.Task) []int {
+		result := []int{}
+		for _, task := range tasks {
+			result = append(result, task.Id)
+		}
+		return result
+	}
+
+	createTasks := func() []*models.Task {
+		due := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+		tasks := []*models.Task{
+			createTask(1, models.DONE),
+			createTask(2, models.TODO),
+			createTask(3, models.IN_PROGRESS),
+			createTask(4, models.TODO),
+		}
+		tasks[0].Priority = models.LOW
+		tasks[1].Priority = models.HIGH
+		tasks[2].Due = &due
+		tasks[3].Priority = models.HIGH
+		return tasks
+	}
+
+	t.Run("✅ Should sort by several keys keeping ties in store order", func(t *testing.T) {
+		sort, err := ParseSort("-priority,status")
+
+		asserts.Nil(err)
+		asserts.Equal([]int{2, 4, 1, 3}, ids(models.Query{Sort: sort}.Apply(createTasks())))
+	})
+
+	t.Run("✅ Should sort tasks without a due date last", func(t *testing.T) {
+		ascending, _ := ParseSort("due")
+		descending, _ := ParseSort("-due,id")
+
+		asserts.Equal(3, models.Query{Sort: ascending}.Apply(createTasks())[0].Id)
+		asserts.Equal([]int{3, 1, 2, 4}, ids(models.Query{Sort: descending}.Apply(createTasks())))
+	})
+
+	t.Run("✅ Should apply offset and limit after sorting", func(t *testing.T) {
+		sort, _ := ParseSort("-id")
+
+		asserts.Equal([]int{3, 2}, ids(models.Query{Sort: sort, Offset: 1, Limit: 2}.Apply(createTasks())))
+		asserts.Empty(models.Query{Offset: 10}.Apply(createTasks()))
+	})
+
+	t.Run("❌ Should return an error for an unknown sort field", func(t *testing.T) {
+		sort, err := ParseSort("created,size")
+
+		asserts.Nil(sort)
+		asserts.EqualError(err, `unknown sort field "size"`)
+	})
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"task-tracker/configs"
 	"task-tracker/services"
 	"task-tracker/stores"
 )
 
 func main() {
-	jsonStore := stores.NewJsonTaskStore("tasks.json")
-	commandLine := services.NewCommandLine(jsonStore)
+	config, err := configs.LoadConfig("config.json")
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	commandLine := services.NewCommandLine(stores.Open, config)
 	commandLine.Run()
 }
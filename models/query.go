@@ -0,0 +1,65 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Query selects tasks from a TaskStore. A nil Filter matches every task, a
+// nil Sort keeps store order and a zero Limit returns every match. Where
+// lets a store with indexes skip tasks before Filter runs.
+type Query struct {
+	Filter func(*Task) bool
+	Where  Where
+	Sort   func(a, b *Task) int
+	Limit  int
+	Offset int
+}
+
+// Where holds the predicates of a query a store may answer from indexes.
+// Every task Filter accepts must satisfy them, so a store without indexes
+// can ignore them. Each list matches any of its values, case-insensitively
+// for tags and projects, with an empty project matching tasks without one.
+// Due bounds keep the tasks due from DueFrom up to before DueBefore.
+type Where struct {
+	Statuses  []Status
+	Tags      []string
+	Projects  []string
+	DueFrom   *time.Time
+	DueBefore *time.Time
+}
+
+// IsZero reports whether where holds no predicate.
+func (w Where) IsZero() bool {
+	return w.Statuses == nil && w.Tags == nil && w.Projects == nil && w.DueFrom == nil && w.DueBefore == nil
+}
+
+func (q Query) Apply(tasks []*Task) []*Task {
+	result := []*Task{}
+
+	for _, task := range tasks {
+		if q.Filter == nil || q.Filter(task) {
+			result = append(result, task)
+		}
+	}
+
+	if q.Sort != nil {
+		slices.SortStableFunc(result, q.Sort)
+	}
+
+	if q.Offset > 0 {
+		result = result[min(q.Offset, len(result)):]
+	}
+
+	if q.Limit > 0 && q.Limit < len(result) {
+		result = result[:q.Limit]
+	}
+
+	return result
+}
+
+type SearchResult struct {
+	Task    *Task
+	Score   float64
+	Matches []string
+}
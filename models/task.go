@@ -2,6 +2,9 @@ package models
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,20 +16,44 @@ const (
 	NoTaskString        = "No tasks found"
 )
 
+const (
+	LOW    Priority = Priority("Low")
+	MEDIUM Priority = Priority("Medium")
+	HIGH   Priority = Priority("High")
+)
+
 type Task struct {
-	Id          int        `json:"id"`
-	Description string     `json:"description"`
-	Status      Status     `json:"status"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at"`
+	Id          int            `json:"id"`
+	Description string         `json:"description"`
+	Notes       string         `json:"notes,omitempty"`
+	Status      Status         `json:"status"`
+	Priority    Priority       `json:"priority,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Project     string         `json:"project,omitempty"`
+	Assignee    string         `json:"assignee,omitempty"`
+	DependsOn   []int          `json:"depends_on,omitempty"`
+	Due         *time.Time     `json:"due,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   *time.Time     `json:"updated_at"`
+	History     []StatusChange `json:"history,omitempty"`
 }
 
 type Status string
 
+type StatusChange struct {
+	Status Status    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+type Priority string
+
 type TaskStore interface {
 	AddTask(*Task) (*Task, error)
+	ImportTasks([]*Task) ([]*Task, error)
 	RemoveTask(int) (*Task, error)
 	UpdateTask(int, string) error
+	Query(Query) ([]*Task, error)
+	Search(string) ([]SearchResult, error)
 	PrintAll() error
 	PrintTodo() error
 	PrintDone() error
@@ -43,10 +70,153 @@ func (t *Task) PrintTask() {
 	fmt.Printf(taskString, t.Id, t.Description, t.Status, t.CreatedAt.Format(time.DateOnly), t.UpdatedAt.Format("02/01/2006"))
 }
 
+// MarkAs moves the task to status, recording when it happened as a change
+// and as the last update.
 func (t *Task) MarkAs(status Status) {
+	if t.Status == status {
+		return
+	}
+	now := time.Now()
 	t.Status = status
+	t.UpdatedAt = &now
+	t.History = append(t.History, StatusChange{Status: status, At: now})
+}
+
+// EnteredAt returns when the task last moved to status, or nil when the
+// history does not know.
+func (t *Task) EnteredAt(status Status) *time.Time {
+	for i := len(t.History) - 1; i >= 0; i-- {
+		if t.History[i].Status == status {
+			return &t.History[i].At
+		}
+	}
+	return nil
+}
+
+// StartedAt returns when the task first moved to in progress.
+func (t *Task) StartedAt() *time.Time {
+	for i := range t.History {
+		if t.History[i].Status == IN_PROGRESS {
+			return &t.History[i].At
+		}
+	}
+	return nil
+}
+
+// CompletedAt returns when a done task was finished.
+func (t *Task) CompletedAt() *time.Time {
+	if t.Status != DONE {
+		return nil
+	}
+	return t.EnteredAt(DONE)
+}
+
+// IsOverdue reports whether an unfinished task was due before the day of now.
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.Due == nil || t.Status == DONE {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return t.Due.Before(today)
+}
+
+// BlockedBy returns the dependencies of an unfinished task that are not done
+// yet, looking them up in tasks by ID. Dependencies missing from tasks do not
+// block it.
+func (t *Task) BlockedBy(tasks map[int]*Task) []int {
+	blockers := []int{}
+	if t.Status == DONE {
+		return blockers
+	}
+	for _, id := range t.DependsOn {
+		if dependency, ok := tasks[id]; ok && dependency.Status != DONE {
+			blockers = append(blockers, id)
+		}
+	}
+	return blockers
+}
+
+func (t *Task) HasTag(tag string) bool {
+	for _, v := range t.Tags {
+		if strings.EqualFold(v, tag) {
+			return true
+		}
+	}
+	return false
 }
 
 func (s Status) String() string {
 	return string(s)
 }
+
+// Rank orders statuses along the workflow, unknown statuses rank 0.
+func (s Status) Rank() int {
+	switch s {
+	case TODO:
+		return 1
+	case IN_PROGRESS:
+		return 2
+	case DONE:
+		return 3
+	}
+	return 0
+}
+
+func ParseStatus(value string) (Status, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "todo", "to-do", "to do":
+		return TODO, nil
+	case "in-progress", "in progress", "inprogress":
+		return IN_PROGRESS, nil
+	case "done":
+		return DONE, nil
+	}
+	return "", fmt.Errorf("invalid status %q, expected: todo, in-progress, done", value)
+}
+
+func (p Priority) String() string {
+	return string(p)
+}
+
+// Rank orders priorities from lowest to highest, tasks without priority rank 0.
+func (p Priority) Rank() int {
+	switch p {
+	case LOW:
+		return 1
+	case MEDIUM:
+		return 2
+	case HIGH:
+		return 3
+	}
+	return 0
+}
+
+func ParsePriority(value string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "low", "l":
+		return LOW, nil
+	case "medium", "med", "m":
+		return MEDIUM, nil
+	case "high", "h":
+		return HIGH, nil
+	}
+	return "", fmt.Errorf("invalid priority %q, expected: low, medium, high", value)
+}
+
+// ParseDependencies reads a comma separated list of task IDs.
+func ParseDependencies(value string) ([]int, error) {
+	ids := []int{}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimPrefix(strings.TrimSpace(part), "#"); part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid dependency %q, expected a task ID", part)
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,203 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+type (
+	// Agenda lists unfinished tasks due in the next Days days, grouped by day
+	// after the overdue ones.
+	Agenda struct {
+		Days       int
+		DateFormat string
+		Now        time.Time
+		Painter    *Painter
+		Humanizer  *Humanizer
+	}
+
+	// Calendar draws a month grid with the number of unfinished tasks due
+	// each day, weeks starting on Monday.
+	Calendar struct {
+		Month   time.Time
+		Now     time.Time
+		Painter *Painter
+	}
+)
+
+// Tasks returns the tasks the agenda shows, earliest due first.
+func (a *Agenda) Tasks(tasks []*models.Task) []*models.Task {
+	end := StartOfDay(a.Now).AddDate(0, 0, a.Days)
+
+	return models.Query{Filter: func(task *models.Task) bool {
+		return task.Due.Before(end)
+	}}.Apply(dueTasks(tasks))
+}
+
+func (a *Agenda) Render(w io.Writer, tasks []*models.Task) error {
+	today := StartOfDay(a.Now)
+	end := today.AddDate(0, 0, a.Days)
+
+	overdue := []*models.Task{}
+	days := map[string][]*models.Task{}
+
+	for _, task := range a.Tasks(tasks) {
+		switch {
+		case task.IsOverdue(a.Now):
+			overdue = append(overdue, task)
+		default:
+			day := task.Due.Local().Format(time.DateOnly)
+			days[day] = append(days[day], task)
+		}
+	}
+
+	var builder strings.Builder
+
+	if len(overdue) > 0 {
+		builder.WriteString(a.Painter.Paint("overdue", "Overdue") + "\n")
+		for _, task := range overdue {
+			due := "due " + a.formatDate(*task.Due)
+			if a.Humanizer != nil {
+				due = a.Humanizer.Due(*task.Due, a.Now)
+			}
+			builder.WriteString(fmt.Sprintf("  #%d %s (%s)\n", task.Id, task.Description, due))
+		}
+	}
+
+	for day := today; day.Before(end); day = day.AddDate(0, 0, 1) {
+		dayTasks := days[day.Format(time.DateOnly)]
+		if len(dayTasks) == 0 {
+			continue
+		}
+
+		title := day.Format("Mon 02 Jan")
+		style := "header"
+		switch {
+		case day.Equal(today):
+			title, style = "Today, "+title, "today"
+		case day.Equal(today.AddDate(0, 0, 1)):
+			title = "Tomorrow, " + title
+		}
+
+		builder.WriteString(a.Painter.Paint(style, title) + "\n")
+		for _, task := range dayTasks {
+			line := fmt.Sprintf("  #%d %s", task.Id, task.Description)
+			if task.Priority != "" {
+				line += " " + a.Painter.Paint(strings.ToLower(task.Priority.String()), "["+task.Priority.String()+"]")
+			}
+			builder.WriteString(line + "\n")
+		}
+	}
+
+	if builder.Len() == 0 {
+		builder.WriteString(fmt.Sprintf("Nothing due in the next %d days\n", a.Days))
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func (a *Agenda) formatDate(date time.Time) string {
+	if a.DateFormat == "" {
+		return date.Local().Format(time.DateOnly)
+	}
+	return date.Local().Format(a.DateFormat)
+}
+
+// Tasks returns the tasks due in the month of the calendar, earliest first.
+func (c *Calendar) Tasks(tasks []*models.Task) []*models.Task {
+	return models.Query{Filter: func(task *models.Task) bool {
+		due := task.Due.Local()
+		return due.Year() == c.Month.Year() && due.Month() == c.Month.Month()
+	}}.Apply(dueTasks(tasks))
+}
+
+func (c *Calendar) Render(w io.Writer, tasks []*models.Task) error {
+	first := time.Date(c.Month.Year(), c.Month.Month(), 1, 0, 0, 0, 0, time.Local)
+	last := first.AddDate(0, 1, -1)
+	today := StartOfDay(c.Now)
+
+	counts := map[int]int{}
+	overdue := map[int]bool{}
+	for _, task := range c.Tasks(tasks) {
+		day := task.Due.Local().Day()
+		counts[day]++
+		overdue[day] = overdue[day] || task.IsOverdue(c.Now)
+	}
+
+	var builder strings.Builder
+	title := first.Format("January 2006")
+	builder.WriteString(strings.Repeat(" ", max((6*calendarCell+2-len(title))/2, 0)) + c.Painter.Paint("header", title) + "\n")
+	names := ""
+	for _, name := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		names += fmt.Sprintf("%-*s", calendarCell, name)
+	}
+	builder.WriteString(strings.TrimRight(names, " ") + "\n")
+
+	offset := (int(first.Weekday()) + 6) % 7
+	builder.WriteString(strings.Repeat(" ", offset*calendarCell))
+
+	for day := 1; day <= last.Day(); day++ {
+		cell := fmt.Sprintf("%2d", day)
+		if counts[day] > 0 {
+			cell += fmt.Sprintf("[%d]", counts[day])
+		}
+		padding := strings.Repeat(" ", max(calendarCell-len(cell), 1))
+
+		date := first.AddDate(0, 0, day-1)
+		switch {
+		case date.Equal(today):
+			cell = c.Painter.Paint("today", cell)
+		case overdue[day]:
+			cell = c.Painter.Paint("overdue", cell)
+		}
+
+		builder.WriteString(cell)
+		if (offset+day)%7 == 0 || day == last.Day() {
+			builder.WriteString("\n")
+		} else {
+			builder.WriteString(padding)
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+const calendarCell = 7
+
+// ParseMonth accepts YYYY-MM, a month number or a month name, the latter two
+// in the year of now. An empty value is the month of now.
+func ParseMonth(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local), nil
+	}
+
+	if month, err := time.ParseInLocation("2006-01", value, time.Local); err == nil {
+		return month, nil
+	}
+
+	for _, layout := range []string{"1", "January", "Jan"} {
+		if month, err := time.Parse(layout, value); err == nil {
+			return time.Date(now.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid month %q, expected YYYY-MM, a month number or name", value)
+}
+
+// dueTasks returns the unfinished tasks with a due date, earliest first.
+func dueTasks(tasks []*models.Task) []*models.Task {
+	result := models.Query{Filter: func(task *models.Task) bool {
+		return task.Due != nil && task.Status != models.DONE
+	}}.Apply(tasks)
+
+	slices.SortStableFunc(result, func(a, b *models.Task) int {
+		return a.Due.Compare(*b.Due)
+	})
+	return result
+}
@@ -0,0 +1,83 @@
+package renderers
+
+import (
+	"bytes"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgenda(t *testing.T) {
+	asserts := assert.New(t)
+	now := time.Date(2024, 8, 27, 10, 0, 0, 0, time.Local)
+
+	createTasks := func() []*models.Task {
+		dates := []time.Time{
+			time.Date(2024, 8, 28, 0, 0, 0, 0, time.Local),
+			time.Date(2024, 8, 20, 0, 0, 0, 0, time.Local),
+			time.Date(2024, 8, 27, 0, 0, 0, 0, time.Local),
+			time.Date(2024, 9, 10, 0, 0, 0, 0, time.Local),
+			time.Date(2024, 8, 27, 0, 0, 0, 0, time.Local),
+		}
+		tasks := []*models.Task{}
+		for i := range dates {
+			task := createTask(i+1, models.TODO)
+			task.Due = &dates[i]
+			tasks = append(tasks, task)
+		}
+		tasks[4].Status = models.DONE
+		tasks[2].Priority = models.HIGH
+		return append(tasks, createTask(6, models.TODO))
+	}
+
+	t.Run("✅ Should group the next days after overdue tasks", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := (&Agenda{Days: 7, Now: now}).Render(&buffer, createTasks())
+
+		asserts.Nil(err)
+		asserts.Equal(""+
+			"Overdue\n"+
+			"  #2 Task 2 (due 2024-08-20)\n"+
+			"Today, Tue 27 Aug\n"+
+			"  #3 Task 3 [High]\n"+
+			"Tomorrow, Wed 28 Aug\n"+
+			"  #1 Task 1\n", buffer.String())
+	})
+
+	t.Run("✅ Should say when nothing is due", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := (&Agenda{Days: 3, Now: now}).Render(&buffer, createTasks()[5:])
+
+		asserts.Nil(err)
+		asserts.Equal("Nothing due in the next 3 days\n", buffer.String())
+	})
+
+	t.Run("✅ Should count unfinished tasks per day of the month", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := (&Calendar{Month: now, Now: now}).Render(&buffer, createTasks())
+
+		asserts.Nil(err)
+		asserts.Equal(""+
+			"                August 2024\n"+
+			"Mo     Tu     We     Th     Fr     Sa     Su\n"+
+			"                      1      2      3      4\n"+
+			" 5      6      7      8      9     10     11\n"+
+			"12     13     14     15     16     17     18\n"+
+			"19     20[1]  21     22     23     24     25\n"+
+			"26     27[1]  28[1]  29     30     31\n", buffer.String())
+	})
+
+	t.Run("✅ Should parse months as numbers, names or YYYY-MM", func(t *testing.T) {
+		number, _ := ParseMonth("9", now)
+		name, _ := ParseMonth("february", now)
+		full, _ := ParseMonth("2023-12", now)
+		_, err := ParseMonth("13", now)
+
+		asserts.Equal("2024-09", number.Format("2006-01"))
+		asserts.Equal("2024-02", name.Format("2006-01"))
+		asserts.Equal("2023-12", full.Format("2006-01"))
+		asserts.EqualError(err, `invalid month "13", expected YYYY-MM, a month number or name`)
+	})
+}
@@ -0,0 +1,212 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"unicode/utf8"
+)
+
+const (
+	boardGap          = "   "
+	minBoardColumn    = 16
+	defaultBoardWidth = 120
+)
+
+// Board lays tasks out as one column per status, optionally split into
+// swimlanes by priority, tag, project or assignee.
+type Board struct {
+	Width   int
+	GroupBy string
+	Painter *Painter
+}
+
+var BoardGroups = []string{"priority", "tag", "project", "assignee"}
+
+var workflow = []models.Status{models.TODO, models.IN_PROGRESS, models.DONE}
+
+func (b *Board) Render(w io.Writer, tasks []*models.Task) error {
+	if b.GroupBy != "" && !slices.Contains(BoardGroups, b.GroupBy) {
+		return fmt.Errorf("invalid board group %q, expected: %s", b.GroupBy, strings.Join(BoardGroups, ", "))
+	}
+
+	statuses := slices.Clone(workflow)
+	for _, task := range tasks {
+		if !slices.Contains(statuses, task.Status) {
+			statuses = append(statuses, task.Status)
+		}
+	}
+
+	width := b.Width
+	if width <= 0 {
+		width = defaultBoardWidth
+	}
+	columnWidth := max((width-len(boardGap)*(len(statuses)-1))/len(statuses), minBoardColumn)
+
+	headers := []string{}
+	styles := []string{}
+	rules := []string{}
+	for _, status := range statuses {
+		count := 0
+		for _, task := range tasks {
+			if task.Status == status {
+				count++
+			}
+		}
+		headers = append(headers, truncate(fmt.Sprintf("%s (%d)", status, count), columnWidth))
+		styles = append(styles, StatusElement(status))
+		rules = append(rules, strings.Repeat("─", columnWidth))
+	}
+
+	lines := [][]string{headers, rules}
+	lineStyles := [][]string{styles, make([]string, len(statuses))}
+
+	for _, lane := range b.lanes(tasks) {
+		if b.GroupBy != "" {
+			lines = append(lines, []string{}, []string{b.Painter.Paint("header", "▸ "+lane.name)})
+			lineStyles = append(lineStyles, nil, nil)
+		}
+
+		columns := [][]string{}
+		for _, status := range statuses {
+			cards := []string{}
+			for _, task := range lane.tasks {
+				if task.Status == status {
+					cards = append(cards, wrap("#"+strconv.Itoa(task.Id)+" "+task.Description, columnWidth)...)
+				}
+			}
+			columns = append(columns, cards)
+		}
+
+		height := 0
+		for _, cards := range columns {
+			height = max(height, len(cards))
+		}
+
+		for row := 0; row < height; row++ {
+			line := []string{}
+			for _, cards := range columns {
+				cell := ""
+				if row < len(cards) {
+					cell = cards[row]
+				}
+				line = append(line, cell)
+			}
+			lines = append(lines, line)
+			lineStyles = append(lineStyles, make([]string, len(statuses)))
+		}
+	}
+
+	for i, line := range lines {
+		var builder strings.Builder
+		last := len(line) - 1
+		for last > 0 && line[last] == "" {
+			last--
+		}
+
+		for j := 0; j <= last && j < len(line); j++ {
+			style := ""
+			if lineStyles[i] != nil {
+				style = lineStyles[i][j]
+			}
+			builder.WriteString(b.Painter.Paint(style, line[j]))
+			if j < last {
+				builder.WriteString(strings.Repeat(" ", max(columnWidth-utf8.RuneCountInString(line[j]), 0)) + boardGap)
+			}
+		}
+
+		if _, err := fmt.Fprintln(w, builder.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type lane struct {
+	name  string
+	tasks []*models.Task
+}
+
+func (b *Board) lanes(tasks []*models.Task) []lane {
+	switch b.GroupBy {
+	case "priority":
+		lanes := []lane{}
+		for _, priority := range []models.Priority{models.HIGH, models.MEDIUM, models.LOW, ""} {
+			name := priority.String()
+			if name == "" {
+				name = "No priority"
+			}
+			matched := models.Query{Filter: func(task *models.Task) bool {
+				return task.Priority == priority
+			}}.Apply(tasks)
+			if len(matched) > 0 {
+				lanes = append(lanes, lane{name, matched})
+			}
+		}
+		return lanes
+	case "tag":
+		names := []string{}
+		byTag := map[string][]*models.Task{}
+		for _, task := range tasks {
+			tags := task.Tags
+			if len(tags) == 0 {
+				tags = []string{""}
+			}
+			for _, tag := range tags {
+				tag = strings.ToLower(tag)
+				if _, ok := byTag[tag]; !ok {
+					names = append(names, tag)
+				}
+				byTag[tag] = append(byTag[tag], task)
+			}
+		}
+		slices.Sort(names)
+
+		lanes := []lane{}
+		for _, name := range names {
+			if name != "" {
+				lanes = append(lanes, lane{name, byTag[name]})
+			}
+		}
+		if untagged, ok := byTag[""]; ok {
+			lanes = append(lanes, lane{"Untagged", untagged})
+		}
+		return lanes
+	case "project":
+		return fieldLanes(tasks, "No project", func(task *models.Task) string { return task.Project })
+	case "assignee":
+		return fieldLanes(tasks, "Unassigned", func(task *models.Task) string { return task.Assignee })
+	}
+	return []lane{{"", tasks}}
+}
+
+// fieldLanes makes a lane per value of a text field in any case, sorted,
+// with the tasks without one last under empty.
+func fieldLanes(tasks []*models.Task, empty string, get func(*models.Task) string) []lane {
+	names := []string{}
+	byValue := map[string]*lane{}
+	for _, task := range tasks {
+		value := strings.TrimSpace(get(task))
+		key := strings.ToLower(value)
+		if _, ok := byValue[key]; !ok {
+			names = append(names, key)
+			byValue[key] = &lane{name: value}
+		}
+		byValue[key].tasks = append(byValue[key].tasks, task)
+	}
+	slices.Sort(names)
+
+	lanes := []lane{}
+	for _, name := range names {
+		if name != "" {
+			lanes = append(lanes, *byValue[name])
+		}
+	}
+	if missing, ok := byValue[""]; ok {
+		lanes = append(lanes, lane{empty, missing.tasks})
+	}
+	return lanes
+}
@@ -0,0 +1,75 @@
+package renderers
+
+import (
+	"bytes"
+	"task-tracker/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoard(t *testing.T) {
+	asserts := assert.New(t)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{
+			createTask(1, models.TODO),
+			createTask(2, models.IN_PROGRESS),
+			createTask(3, models.TODO),
+			createTask(4, models.Status("Blocked")),
+		}
+		tasks[0].Priority = models.HIGH
+		tasks[2].Description = "Task with a longer description"
+		return tasks
+	}
+
+	render := func(board *Board, tasks []*models.Task) (string, error) {
+		var buffer bytes.Buffer
+		err := board.Render(&buffer, tasks)
+		return buffer.String(), err
+	}
+
+	t.Run("✅ Should render a column per status with counts", func(t *testing.T) {
+		result, err := render(&Board{Width: 70}, createTasks())
+
+		asserts.Nil(err)
+		asserts.Equal(""+
+			"To do (2)          In progress (1)    Done (0)           Blocked (1)\n"+
+			"────────────────   ────────────────   ────────────────   ────────────────\n"+
+			"#1 Task 1          #2 Task 2                             #4 Task 4\n"+
+			"#3 Task with a\n"+
+			"longer\n"+
+			"description\n", result)
+	})
+
+	t.Run("✅ Should split the board into priority swimlanes", func(t *testing.T) {
+		result, err := render(&Board{Width: 60}, createTasks()[:2])
+		grouped, groupErr := render(&Board{Width: 60, GroupBy: "priority"}, createTasks()[:2])
+
+		asserts.Nil(err)
+		asserts.Nil(groupErr)
+		asserts.NotContains(result, "▸")
+		asserts.Contains(grouped, "\n▸ High\n#1 Task 1\n\n▸ No priority\n")
+	})
+
+	t.Run("✅ Should split the board into project and assignee swimlanes", func(t *testing.T) {
+		tasks := createTasks()[:3]
+		tasks[0].Project, tasks[0].Assignee = "Web", "ana"
+		tasks[1].Project, tasks[1].Assignee = "api", "ana"
+		tasks[2].Project = "web"
+
+		byProject, err := render(&Board{Width: 60, GroupBy: "project"}, tasks)
+		byAssignee, assigneeErr := render(&Board{Width: 60, GroupBy: "assignee"}, tasks)
+
+		asserts.Nil(err)
+		asserts.Nil(assigneeErr)
+		asserts.Contains(byProject, "\n▸ api\n                     #2 Task 2\n\n▸ Web\n#1 Task 1\n#3 Task with a\n")
+		asserts.Contains(byAssignee, "\n▸ ana\n#1 Task 1            #2 Task 2\n\n▸ Unassigned\n#3 Task with a\n")
+	})
+
+	t.Run("❌ Should return an error for an unknown group", func(t *testing.T) {
+		_, err := render(&Board{GroupBy: "owner"}, createTasks())
+
+		asserts.EqualError(err, `invalid board group "owner", expected: priority, tag, project, assignee`)
+	})
+}
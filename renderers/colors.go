@@ -0,0 +1,170 @@
+package renderers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+type (
+	// Theme maps output elements (statuses, priorities, overdue, header,
+	// match) to space separated styles such as "bold red".
+	Theme map[string]string
+
+	Painter struct {
+		enabled bool
+		theme   Theme
+	}
+)
+
+var DefaultTheme = Theme{
+	"todo":        "yellow",
+	"in-progress": "cyan",
+	"done":        "green",
+	"high":        "bold red",
+	"medium":      "yellow",
+	"low":         "blue",
+	"overdue":     "bold red",
+	"today":       "bold",
+	"header":      "bold",
+	"match":       "bold yellow",
+}
+
+var styles = map[string]int{
+	"bold":      1,
+	"dim":       2,
+	"italic":    3,
+	"underline": 4,
+	"reverse":   7,
+	"black":     30,
+	"red":       31,
+	"green":     32,
+	"yellow":    33,
+	"blue":      34,
+	"magenta":   35,
+	"cyan":      36,
+	"white":     37,
+	"gray":      90,
+}
+
+// NewPainter decides whether to color output written to file. The mode is
+// auto, always or never; auto colors terminals unless NO_COLOR is set to a
+// non-empty value.
+func NewPainter(mode string, theme Theme, file *os.File) (*Painter, error) {
+	merged := Theme{}
+	for element, style := range DefaultTheme {
+		merged[element] = style
+	}
+	for element, style := range theme {
+		if _, err := sgr(style); err != nil {
+			return nil, fmt.Errorf("invalid theme style for %q: %w", element, err)
+		}
+		merged[element] = style
+	}
+
+	painter := &Painter{theme: merged}
+
+	switch mode {
+	case "", "auto":
+		painter.enabled = os.Getenv("NO_COLOR") == "" && IsTerminal(file)
+	case "always":
+		painter.enabled = true
+	case "never":
+		painter.enabled = false
+	default:
+		return nil, fmt.Errorf("invalid color mode %q, expected: auto, always, never", mode)
+	}
+	return painter, nil
+}
+
+func (p *Painter) Enabled() bool {
+	return p != nil && p.enabled
+}
+
+// Paint wraps text in the style the theme gives to element.
+func (p *Painter) Paint(element string, text string) string {
+	if !p.Enabled() || element == "" || text == "" {
+		return text
+	}
+
+	codes, _ := sgr(p.theme[element])
+	if codes == "" {
+		return text
+	}
+	return "\x1b[" + codes + "m" + text + "\x1b[0m"
+}
+
+// PaintStyle paints text with a theme element or, failing that, a style
+// such as "bold red".
+func (p *Painter) PaintStyle(style string, text string) (string, error) {
+	if p != nil {
+		if _, ok := p.theme[style]; ok {
+			return p.Paint(style, text), nil
+		}
+	}
+
+	codes, err := sgr(style)
+	if err != nil || !p.Enabled() || codes == "" || text == "" {
+		return text, err
+	}
+	return "\x1b[" + codes + "m" + text + "\x1b[0m", nil
+}
+
+func (p *Painter) Status(status models.Status) string {
+	return p.Paint(StatusElement(status), status.String())
+}
+
+// StatusElement is the theme element of a status, also used as its key in
+// structured output.
+func StatusElement(status models.Status) string {
+	switch status {
+	case models.TODO:
+		return "todo"
+	case models.IN_PROGRESS:
+		return "in-progress"
+	case models.DONE:
+		return "done"
+	}
+	return strings.ToLower(strings.Join(strings.Fields(status.String()), "-"))
+}
+
+// DueElement is the theme element of a due date: overdue, today or none.
+func DueElement(task *models.Task, now time.Time) string {
+	switch {
+	case task.Due == nil:
+		return ""
+	case task.IsOverdue(now):
+		return "overdue"
+	case task.Status != models.DONE && sameDay(*task.Due, now):
+		return "today"
+	}
+	return ""
+}
+
+func sameDay(a time.Time, b time.Time) bool {
+	return a.Local().Format(time.DateOnly) == b.Local().Format(time.DateOnly)
+}
+
+func sgr(style string) (string, error) {
+	codes := []string{}
+
+	for _, name := range strings.Fields(strings.ToLower(style)) {
+		if code, ok := styles[name]; ok {
+			codes = append(codes, strconv.Itoa(code))
+			continue
+		}
+		if bright, ok := strings.CutPrefix(name, "bright-"); ok && styles[bright] >= 30 {
+			codes = append(codes, strconv.Itoa(styles[bright]+60))
+			continue
+		}
+		if _, err := strconv.Atoi(name); err == nil {
+			codes = append(codes, name)
+			continue
+		}
+		return "", fmt.Errorf("unknown style %q", name)
+	}
+	return strings.Join(codes, ";"), nil
+}
@@ -0,0 +1,19 @@
+package renderers
+
+import "time"
+
+// StartOfDay returns local midnight of the day of date.
+func StartOfDay(date time.Time) time.Time {
+	date = date.Local()
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// DaysBetween counts the local calendar days from one date to another,
+// negative when to is on an earlier day. Days around a daylight saving
+// change still count as one.
+func DaysBetween(from time.Time, to time.Time) int {
+	from, to = from.Local(), to.Local()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start) / (24 * time.Hour))
+}
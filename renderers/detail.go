@@ -0,0 +1,68 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"task-tracker/models"
+)
+
+// detailColumns are the table columns RenderDetail shows under the
+// description, in order.
+var detailColumns = []string{"status", "priority", "tags", "project", "assignee", "due", "created", "updated"}
+
+// RenderDetail writes one task field by field, formatting values as the
+// table columns of the same name do and leaving out empty ones.
+func (t *Table) RenderDetail(w io.Writer, task *models.Task) error {
+	var builder strings.Builder
+	builder.WriteString(t.Painter.Paint("header", fmt.Sprintf("#%d %s", task.Id, task.Description)) + "\n")
+
+	line := func(label string, value string) {
+		builder.WriteString(fmt.Sprintf("  %-11s %s\n", label+":", value))
+	}
+
+	for _, name := range detailColumns {
+		column := columns[name]
+		value := column.value(task, t)
+		if value == "" {
+			continue
+		}
+		if column.style != nil {
+			value = t.Painter.Paint(column.style(task, t), value)
+		}
+		line(strings.ToUpper(name[:1])+strings.ToLower(column.header[1:]), value)
+	}
+
+	if len(task.DependsOn) > 0 {
+		ids := []string{}
+		for _, id := range task.DependsOn {
+			ids = append(ids, fmt.Sprintf("#%d", id))
+		}
+		line("Depends on", strings.Join(ids, ", "))
+	}
+
+	if task.Notes != "" {
+		builder.WriteString("\n")
+		for _, note := range strings.Split(task.Notes, "\n") {
+			builder.WriteString("  " + note + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// RenderHistory writes when the task was created and every status change
+// it went through since, oldest first.
+func (t *Table) RenderHistory(w io.Writer, task *models.Task) error {
+	var builder strings.Builder
+	builder.WriteString(t.Painter.Paint("header", fmt.Sprintf("#%d %s", task.Id, task.Description)) + "\n")
+	builder.WriteString(fmt.Sprintf("  %s  Created\n", t.formatDate(&task.CreatedAt)))
+
+	for _, change := range task.History {
+		builder.WriteString(fmt.Sprintf("  %s  %s\n", t.formatDate(&change.At), t.Painter.Status(change.Status)))
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
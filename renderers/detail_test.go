@@ -0,0 +1,72 @@
+package renderers
+
+import (
+	"bytes"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetail(t *testing.T) {
+	asserts := assert.New(t)
+
+	createDetailedTask := func() *models.Task {
+		task := createTask(3, models.TODO)
+		task.Priority = models.HIGH
+		task.Tags = []string{"home", "urgent"}
+		task.Project = "Kitchen"
+		task.DependsOn = []int{1, 2}
+		task.Notes = "Check the seal first\nBuy a spare"
+		updated := time.Date(2024, 8, 26, 17, 0, 0, 0, time.UTC)
+		task.Status = models.DONE
+		task.UpdatedAt = &updated
+		task.History = []models.StatusChange{
+			{Status: models.IN_PROGRESS, At: time.Date(2024, 8, 25, 9, 0, 0, 0, time.UTC)},
+			{Status: models.DONE, At: updated},
+		}
+		return task
+	}
+
+	t.Run("✅ Should list the set fields of a task and its notes", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := (&Table{}).RenderDetail(&buffer, createDetailedTask())
+		asserts.Nil(err)
+
+		asserts.Equal(""+
+			"#3 Task 3\n"+
+			"  Status:     Done\n"+
+			"  Priority:   High\n"+
+			"  Tags:       home,urgent\n"+
+			"  Project:    Kitchen\n"+
+			"  Created:    2024-08-24\n"+
+			"  Updated:    2024-08-26\n"+
+			"  Depends on: #1, #2\n"+
+			"\n"+
+			"  Check the seal first\n"+
+			"  Buy a spare\n", buffer.String())
+	})
+
+	t.Run("✅ Should list the status changes of a task after its creation", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := (&Table{DateFormat: "2006-01-02 15:04"}).RenderHistory(&buffer, createDetailedTask())
+		asserts.Nil(err)
+
+		asserts.Equal(""+
+			"#3 Task 3\n"+
+			"  2024-08-24 00:00  Created\n"+
+			"  2024-08-25 09:00  In progress\n"+
+			"  2024-08-26 17:00  Done\n", buffer.String())
+	})
+
+	t.Run("✅ Should write the status changes as history records", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := WriteRecords(&buffer, NDJSON, "", "history", HistoryRecords(createDetailedTask()))
+		asserts.Nil(err)
+
+		asserts.Equal(""+
+			`{"schema_version":1,"task_id":3,"status":"in-progress","at":"2024-08-25T09:00:00Z"}`+"\n"+
+			`{"schema_version":1,"task_id":3,"status":"done","at":"2024-08-26T17:00:00Z"}`+"\n", buffer.String())
+	})
+}
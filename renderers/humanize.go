@@ -0,0 +1,147 @@
+package renderers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ABSOLUTE = "absolute"
+	RELATIVE = "relative"
+	COMPACT  = "compact"
+)
+
+type (
+	// Humanizer describes dates relative to now in a locale, with short
+	// units ("2h") when Short is set.
+	Humanizer struct {
+		Locale string
+		Short  bool
+	}
+
+	locale struct {
+		justNow, ago, in, today, due, overdue string
+		units                                 [][3]string
+	}
+)
+
+var units = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+}
+
+// locales hold the phrases of each language, units are singular, plural
+// and short names in the order of units.
+var locales = map[string]locale{
+	"en": {
+		justNow: "just now", ago: "%s ago", in: "in %s",
+		today: "due today", due: "due in %s", overdue: "overdue by %s",
+		units: [][3]string{
+			{"year", "years", "y"}, {"month", "months", "mo"}, {"week", "weeks", "w"},
+			{"day", "days", "d"}, {"hour", "hours", "h"}, {"minute", "minutes", "m"},
+		},
+	},
+	"es": {
+		justNow: "ahora mismo", ago: "hace %s", in: "en %s",
+		today: "vence hoy", due: "vence en %s", overdue: "vencida hace %s",
+		units: [][3]string{
+			{"año", "años", "a"}, {"mes", "meses", "me"}, {"semana", "semanas", "sem"},
+			{"día", "días", "d"}, {"hora", "horas", "h"}, {"minuto", "minutos", "min"},
+		},
+	},
+}
+
+// ParseDates validates how dates are shown: absolute, relative or compact
+// (relative with short units).
+func ParseDates(value string) (string, error) {
+	switch value = strings.ToLower(value); value {
+	case "":
+		return ABSOLUTE, nil
+	case ABSOLUTE, RELATIVE, COMPACT:
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid dates %q, expected: absolute, relative, compact", value)
+}
+
+func ParseLocale(value string) (string, error) {
+	value = strings.ToLower(value)
+	if value == "" {
+		return "en", nil
+	}
+	if _, ok := locales[value]; !ok {
+		return "", fmt.Errorf("unsupported locale %q, expected: en, es", value)
+	}
+	return value, nil
+}
+
+// Humanize describes date relative to now, such as "3 days ago" or "in 2 hours".
+func Humanize(date time.Time, now time.Time) string {
+	return Humanizer{}.Since(date, now)
+}
+
+// Since describes date relative to now, such as "3 days ago" or "in 2 hours".
+func (h Humanizer) Since(date time.Time, now time.Time) string {
+	phrases := h.locale()
+	delta := date.Sub(now)
+	if delta > -time.Minute && delta < time.Minute {
+		return phrases.justNow
+	}
+
+	if delta < 0 {
+		return fmt.Sprintf(phrases.ago, h.duration(-delta))
+	}
+	return fmt.Sprintf(phrases.in, h.duration(delta))
+}
+
+// Due describes a due date by calendar days, such as "due in 2 days" or
+// "overdue by 1 day", and by hours on the day it is due.
+func (h Humanizer) Due(due time.Time, now time.Time) string {
+	phrases := h.locale()
+	days := DaysBetween(now, due)
+
+	switch {
+	case days < 0:
+		return fmt.Sprintf(phrases.overdue, h.duration(time.Duration(-days)*24*time.Hour))
+	case days > 0:
+		return fmt.Sprintf(phrases.due, h.duration(time.Duration(days)*24*time.Hour))
+	case due.Sub(now) >= time.Minute:
+		return fmt.Sprintf(phrases.due, h.duration(due.Sub(now)))
+	}
+	return phrases.today
+}
+
+func (h Humanizer) locale() locale {
+	if phrases, ok := locales[h.Locale]; ok {
+		return phrases
+	}
+	return locales["en"]
+}
+
+func (h Humanizer) duration(delta time.Duration) string {
+	names := h.locale().units
+
+	for i, size := range units {
+		if delta < size {
+			continue
+		}
+
+		count := int(delta / size)
+		switch {
+		case h.Short:
+			return fmt.Sprintf("%d%s", count, names[i][2])
+		case count == 1:
+			return fmt.Sprintf("1 %s", names[i][0])
+		}
+		return fmt.Sprintf("%d %s", count, names[i][1])
+	}
+
+	if h.Short {
+		return "0" + names[len(names)-1][2]
+	}
+	return "1 " + names[len(names)-1][0]
+}
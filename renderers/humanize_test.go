@@ -0,0 +1,64 @@
+package renderers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	asserts := assert.New(t)
+	now := time.Date(2024, 8, 28, 12, 0, 0, 0, time.Local)
+
+	t.Run("✅ Should describe dates relative to now", func(t *testing.T) {
+		asserts.Equal("just now", Humanize(now.Add(-30*time.Second), now))
+		asserts.Equal("3 days ago", Humanize(now.AddDate(0, 0, -3), now))
+		asserts.Equal("in 1 hour", Humanize(now.Add(90*time.Minute), now))
+		asserts.Equal("2 weeks ago", Humanize(now.AddDate(0, 0, -15), now))
+	})
+
+	t.Run("✅ Should describe due dates by calendar day", func(t *testing.T) {
+		humanizer := Humanizer{}
+		today := time.Date(2024, 8, 28, 0, 0, 0, 0, time.Local)
+
+		asserts.Equal("due today", humanizer.Due(today, now))
+		asserts.Equal("due in 2 hours", humanizer.Due(now.Add(2*time.Hour), now))
+		asserts.Equal("due in 1 day", humanizer.Due(today.AddDate(0, 0, 1), now))
+		asserts.Equal("overdue by 1 day", humanizer.Due(today.AddDate(0, 0, -1), now))
+	})
+
+	t.Run("✅ Should count calendar days across a daylight saving change", func(t *testing.T) {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+		local := time.Local
+		time.Local = berlin
+		defer func() { time.Local = local }()
+
+		saturday := time.Date(2024, 3, 30, 12, 0, 0, 0, berlin)
+		asserts.Equal(3, DaysBetween(saturday, time.Date(2024, 4, 2, 0, 0, 0, 0, berlin)))
+		asserts.Equal("due in 3 days", Humanizer{}.Due(time.Date(2024, 4, 2, 9, 0, 0, 0, berlin), saturday))
+		asserts.Equal("overdue by 2 days", Humanizer{}.Due(time.Date(2024, 10, 25, 9, 0, 0, 0, berlin), time.Date(2024, 10, 27, 23, 0, 0, 0, berlin)))
+	})
+
+	t.Run("✅ Should use short units and other locales", func(t *testing.T) {
+		asserts.Equal("due in 2h", Humanizer{Short: true}.Due(now.Add(2*time.Hour), now))
+		asserts.Equal("overdue by 1d", Humanizer{Short: true}.Due(now.AddDate(0, 0, -1), now))
+		asserts.Equal("hace 3 días", Humanizer{Locale: "es"}.Since(now.AddDate(0, 0, -3), now))
+		asserts.Equal("vencida hace 1 día", Humanizer{Locale: "es"}.Due(now.AddDate(0, 0, -1), now))
+		asserts.Equal("vence hoy", Humanizer{Locale: "es"}.Due(now, now))
+	})
+
+	t.Run("❌ Should reject unknown options", func(t *testing.T) {
+		_, err := ParseLocale("fr")
+		asserts.Error(err)
+		_, err = ParseDates("fuzzy")
+		asserts.Error(err)
+
+		dates, err := ParseDates("")
+		asserts.NoError(err)
+		asserts.Equal(ABSOLUTE, dates)
+	})
+}
@@ -0,0 +1,285 @@
+package renderers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+// SchemaVersion is bumped whenever a structured record changes incompatibly.
+const SchemaVersion = 1
+
+type Format string
+
+const (
+	TEXT   Format = Format("text")
+	JSON   Format = Format("json")
+	NDJSON Format = Format("ndjson")
+	YAML   Format = Format("yaml")
+)
+
+type (
+	TaskRecord struct {
+		Id          int        `json:"id"`
+		Description string     `json:"description"`
+		Notes       string     `json:"notes"`
+		Status      string     `json:"status"`
+		Priority    string     `json:"priority"`
+		Tags        []string   `json:"tags"`
+		Project     string     `json:"project"`
+		Assignee    string     `json:"assignee"`
+		DependsOn   []int      `json:"depends_on"`
+		Due         *time.Time `json:"due"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   *time.Time `json:"updated_at"`
+	}
+
+	SearchRecord struct {
+		Score   float64    `json:"score"`
+		Matches []string   `json:"matches"`
+		Task    TaskRecord `json:"task"`
+	}
+
+	HistoryRecord struct {
+		TaskId int       `json:"task_id"`
+		Status string    `json:"status"`
+		At     time.Time `json:"at"`
+	}
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(value)); format {
+	case "":
+		return TEXT, nil
+	case TEXT, JSON, NDJSON, YAML:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid output %q, expected: text, json, ndjson, yaml", value)
+}
+
+func NewTaskRecord(task *models.Task) TaskRecord {
+	tags := task.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	dependsOn := task.DependsOn
+	if dependsOn == nil {
+		dependsOn = []int{}
+	}
+
+	return TaskRecord{
+		Id:          task.Id,
+		Description: task.Description,
+		Notes:       task.Notes,
+		Status:      StatusElement(task.Status),
+		Priority:    strings.ToLower(task.Priority.String()),
+		Tags:        tags,
+		Project:     task.Project,
+		Assignee:    task.Assignee,
+		DependsOn:   dependsOn,
+		Due:         task.Due,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+}
+
+func TaskRecords(tasks []*models.Task) []any {
+	records := []any{}
+	for _, task := range tasks {
+		records = append(records, NewTaskRecord(task))
+	}
+	return records
+}
+
+// HistoryRecords lists the status changes of a task, oldest first.
+func HistoryRecords(task *models.Task) []any {
+	records := []any{}
+	for _, change := range task.History {
+		records = append(records, HistoryRecord{TaskId: task.Id, Status: StatusElement(change.Status), At: change.At})
+	}
+	return records
+}
+
+// WriteRecords writes records under key in a versioned document, or one
+// record per line for ndjson. Action names what a mutating command did and
+// is left out for reads.
+func WriteRecords(w io.Writer, format Format, action string, key string, records []any) error {
+	header := [][2]any{{"schema_version", SchemaVersion}}
+	if action != "" {
+		header = append(header, [2]any{"action", action})
+	}
+
+	if format == NDJSON {
+		for _, record := range records {
+			line, err := withFields(record, header)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if records == nil {
+		records = []any{}
+	}
+
+	document, err := withFields(map[string]any{key: records}, header)
+	if err != nil {
+		return err
+	}
+	return WriteDocument(w, format, json.RawMessage(document))
+}
+
+// WriteDocument writes a single value as indented json, yaml or one ndjson line.
+func WriteDocument(w io.Writer, format Format, document any) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case NDJSON:
+		_, err = fmt.Fprintf(w, "%s\n", data)
+	case YAML:
+		var buffer bytes.Buffer
+		err = jsonToYAML(&buffer, data)
+		if err == nil {
+			_, err = w.Write(buffer.Bytes())
+		}
+	default:
+		var buffer bytes.Buffer
+		err = json.Indent(&buffer, data, "", "  ")
+		if err == nil {
+			buffer.WriteByte('\n')
+			_, err = w.Write(buffer.Bytes())
+		}
+	}
+	return err
+}
+
+// withFields marshals value, an object, with the given fields first.
+func withFields(value any, fields [][2]any) ([]byte, error) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		name, _ := json.Marshal(field[0])
+		content, err := json.Marshal(field[1])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(content)
+	}
+
+	if rest := bytes.TrimPrefix(body, []byte("{")); len(rest) > 1 {
+		buffer.WriteByte(',')
+		buffer.Write(rest)
+	} else {
+		buffer.WriteByte('}')
+	}
+	return buffer.Bytes(), nil
+}
+
+// jsonToYAML rewrites a json document as block style yaml, keeping key order.
+func jsonToYAML(w *bytes.Buffer, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value func(indent int, inList bool) error
+	value = func(indent int, inList bool) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		pad := strings.Repeat("  ", indent)
+
+		switch token {
+		case json.Delim('{'):
+			if !decoder.More() {
+				decoder.Token()
+				w.WriteString(" {}\n")
+				return nil
+			}
+			first := true
+			for decoder.More() {
+				key, _ := decoder.Token()
+				if first && inList {
+					w.WriteString(" ")
+				} else {
+					if first {
+						w.WriteString("\n")
+					}
+					w.WriteString(pad)
+				}
+				first = false
+				w.WriteString(yamlKey(key.(string)) + ":")
+				if err := value(indent+1, false); err != nil {
+					return err
+				}
+			}
+			decoder.Token()
+		case json.Delim('['):
+			if !decoder.More() {
+				decoder.Token()
+				w.WriteString(" []\n")
+				return nil
+			}
+			w.WriteString("\n")
+			for decoder.More() {
+				w.WriteString(pad + "-")
+				if err := value(indent+1, true); err != nil {
+					return err
+				}
+			}
+			decoder.Token()
+		case nil:
+			w.WriteString(" null\n")
+		default:
+			switch v := token.(type) {
+			case string:
+				w.WriteString(" " + strconv.Quote(v) + "\n")
+			case bool:
+				w.WriteString(" " + strconv.FormatBool(v) + "\n")
+			case json.Number:
+				w.WriteString(" " + v.String() + "\n")
+			}
+		}
+		return nil
+	}
+
+	err := value(0, false)
+	if w.Len() > 0 && w.Bytes()[0] == '\n' {
+		trimmed := append([]byte{}, w.Bytes()[1:]...)
+		w.Reset()
+		w.Write(trimmed)
+	}
+	return err
+}
+
+func yamlKey(key string) string {
+	for _, r := range key {
+		if !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
@@ -0,0 +1,105 @@
+package renderers
+
+import (
+	"bytes"
+	"encoding/json"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput(t *testing.T) {
+	asserts := assert.New(t)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{createTask(1, models.IN_PROGRESS), createTask(2, models.DONE)}
+		tasks[0].Priority = models.HIGH
+		tasks[0].Tags = []string{"api"}
+		return tasks
+	}
+
+	write := func(format Format, action string, tasks []*models.Task) string {
+		var buffer bytes.Buffer
+		err := WriteRecords(&buffer, format, action, "tasks", TaskRecords(tasks))
+		asserts.Nil(err)
+		return buffer.String()
+	}
+
+	t.Run("✅ Should write a versioned json document", func(t *testing.T) {
+		result := write(JSON, "", createTasks()[1:])
+
+		asserts.Equal(`{
+  "schema_version": 1,
+  "tasks": [
+    {
+      "id": 2,
+      "description": "Task 2",
+      "notes": "",
+      "status": "done",
+      "priority": "",
+      "tags": [],
+      "project": "",
+      "assignee": "",
+      "depends_on": [],
+      "due": null,
+      "created_at": "2024-08-24T00:00:00Z",
+      "updated_at": null
+    }
+  ]
+}
+`, result)
+	})
+
+	t.Run("✅ Should write an empty task list for no tasks", func(t *testing.T) {
+		asserts.Equal("{\n  \"schema_version\": 1,\n  \"tasks\": []\n}\n", write(JSON, "", nil))
+	})
+
+	t.Run("✅ Should write one record per line with the action", func(t *testing.T) {
+		result := write(NDJSON, "deleted", createTasks())
+
+		asserts.Equal(``+
+			`{"schema_version":1,"action":"deleted","id":1,"description":"Task 1","notes":"","status":"in-progress","priority":"high","tags":["api"],"project":"","assignee":"","depends_on":[],"due":null,"created_at":"2024-08-24T00:00:00Z","updated_at":null}`+"\n"+
+			`{"schema_version":1,"action":"deleted","id":2,"description":"Task 2","notes":"","status":"done","priority":"","tags":[],"project":"","assignee":"","depends_on":[],"due":null,"created_at":"2024-08-24T00:00:00Z","updated_at":null}`+"\n", result)
+	})
+
+	t.Run("✅ Should write yaml keeping the field order", func(t *testing.T) {
+		updated := time.Date(2024, 8, 25, 0, 0, 0, 0, time.UTC)
+		tasks := createTasks()[:1]
+		tasks[0].UpdatedAt = &updated
+		result := write(YAML, "added", tasks)
+
+		asserts.Equal(`schema_version: 1
+action: "added"
+tasks:
+  - id: 1
+    description: "Task 1"
+    notes: ""
+    status: "in-progress"
+    priority: "high"
+    tags:
+      - "api"
+    project: ""
+    assignee: ""
+    depends_on: []
+    due: null
+    created_at: "2024-08-24T00:00:00Z"
+    updated_at: "2024-08-25T00:00:00Z"
+`, result)
+	})
+
+	t.Run("❌ Should return an error for an unknown output", func(t *testing.T) {
+		_, err := ParseFormat("xml")
+
+		asserts.EqualError(err, `invalid output "xml", expected: text, json, ndjson, yaml`)
+	})
+
+	t.Run("❌ Should write nothing for an invalid document", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := WriteDocument(&buffer, JSON, json.RawMessage(`{"tasks": [`))
+
+		asserts.Error(err)
+		asserts.Empty(buffer.String())
+	})
+}
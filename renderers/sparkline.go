@@ -0,0 +1,23 @@
+package renderers
+
+import "strings"
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws one bar per value scaled to the largest value.
+func Sparkline(values []int) string {
+	highest := 0
+	for _, value := range values {
+		highest = max(highest, value)
+	}
+
+	var builder strings.Builder
+	for _, value := range values {
+		if highest == 0 || value <= 0 {
+			builder.WriteRune(sparks[0])
+			continue
+		}
+		builder.WriteRune(sparks[max((value*(len(sparks)-1)+highest-1)/highest, 1)])
+	}
+	return builder.String()
+}
@@ -0,0 +1,283 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"task-tracker/models"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	columnGap           = "  "
+	minDescriptionWidth = 12
+)
+
+type (
+	Table struct {
+		Columns    []string
+		DateFormat string
+		Width      int
+		Wrap       bool
+		Painter    *Painter
+		Now        time.Time
+		// Humanizer shows dates relative to now instead of in DateFormat.
+		Humanizer *Humanizer
+	}
+
+	column struct {
+		header string
+		value  func(*models.Task, *Table) string
+		style  func(*models.Task, *Table) string
+	}
+)
+
+var DefaultColumns = []string{"id", "status", "priority", "due", "description"}
+
+var columns = map[string]column{
+	"id": {"ID", func(task *models.Task, _ *Table) string {
+		return strconv.Itoa(task.Id)
+	}, nil},
+	"description": {"DESCRIPTION", func(task *models.Task, _ *Table) string {
+		return task.Description
+	}, nil},
+	"status": {"STATUS", func(task *models.Task, _ *Table) string {
+		return task.Status.String()
+	}, func(task *models.Task, _ *Table) string {
+		return StatusElement(task.Status)
+	}},
+	"priority": {"PRIORITY", func(task *models.Task, _ *Table) string {
+		return task.Priority.String()
+	}, func(task *models.Task, _ *Table) string {
+		return strings.ToLower(task.Priority.String())
+	}},
+	"tags": {"TAGS", func(task *models.Task, _ *Table) string {
+		return strings.Join(task.Tags, ",")
+	}, nil},
+	"project": {"PROJECT", func(task *models.Task, _ *Table) string {
+		return task.Project
+	}, nil},
+	"assignee": {"ASSIGNEE", func(task *models.Task, _ *Table) string {
+		return task.Assignee
+	}, nil},
+	"due": {"DUE", func(task *models.Task, t *Table) string {
+		if t.Humanizer != nil && task.Due != nil {
+			return t.Humanizer.Due(*task.Due, t.now())
+		}
+		return t.formatDate(task.Due)
+	}, func(task *models.Task, t *Table) string {
+		return DueElement(task, t.now())
+	}},
+	"created": {"CREATED", func(task *models.Task, t *Table) string {
+		return t.formatDate(&task.CreatedAt)
+	}, nil},
+	"updated": {"UPDATED", func(task *models.Task, t *Table) string {
+		return t.formatDate(task.UpdatedAt)
+	}, nil},
+}
+
+// ParseColumns validates a comma separated column list such as
+// "id,status,due,description".
+func ParseColumns(spec string) ([]string, error) {
+	result := []string{}
+
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if name == "tag" {
+			name = "tags"
+		}
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		result = append(result, name)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return result, nil
+}
+
+// Render writes the tasks as aligned columns under a header. When Width is
+// set the description column shrinks to fit, wrapping or truncating its text.
+func (t *Table) Render(w io.Writer, tasks []*models.Task) error {
+	names := t.Columns
+	if len(names) == 0 {
+		names = DefaultColumns
+	}
+
+	rows := [][]string{}
+	rowStyles := [][]string{}
+	header := []string{}
+	headerStyles := []string{}
+	for _, name := range names {
+		c, ok := columns[name]
+		if !ok {
+			return fmt.Errorf("unknown column %q", name)
+		}
+		header = append(header, c.header)
+		headerStyles = append(headerStyles, "header")
+	}
+	rows = append(rows, header)
+	rowStyles = append(rowStyles, headerStyles)
+
+	for _, task := range tasks {
+		row := []string{}
+		style := []string{}
+		for _, name := range names {
+			row = append(row, columns[name].value(task, t))
+			if columns[name].style != nil {
+				style = append(style, columns[name].style(task, t))
+			} else {
+				style = append(style, "")
+			}
+		}
+		rows = append(rows, row)
+		rowStyles = append(rowStyles, style)
+	}
+
+	widths := make([]int, len(names))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	description := -1
+	for i, name := range names {
+		if name == "description" {
+			description = i
+		}
+	}
+
+	if t.Width > 0 && description >= 0 {
+		others := len(columnGap) * (len(names) - 1)
+		for i, width := range widths {
+			if i != description {
+				others += width
+			}
+		}
+		widths[description] = min(widths[description], max(t.Width-others, minDescriptionWidth))
+	}
+
+	for r, row := range rows {
+		lines := [][]string{row}
+
+		if description >= 0 && utf8.RuneCountInString(row[description]) > widths[description] {
+			if t.Wrap {
+				lines = wrapRow(row, description, widths[description])
+			} else {
+				row[description] = truncate(row[description], widths[description])
+			}
+		}
+
+		for _, line := range lines {
+			_, err := fmt.Fprintln(w, t.formatLine(line, rowStyles[r], widths))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *Table) formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+
+	if t.Humanizer != nil {
+		return t.Humanizer.Since(*date, t.now())
+	}
+
+	layout := t.DateFormat
+	if layout == "" {
+		layout = time.DateOnly
+	}
+	return date.Local().Format(layout)
+}
+
+func (t *Table) now() time.Time {
+	if t.Now.IsZero() {
+		return time.Now()
+	}
+	return t.Now
+}
+
+// formatLine pads cells before painting them so colors never shift columns.
+func (t *Table) formatLine(cells []string, styles []string, widths []int) string {
+	var builder strings.Builder
+	last := len(cells) - 1
+	for last > 0 && cells[last] == "" {
+		last--
+	}
+
+	for i, cell := range cells[:last+1] {
+		builder.WriteString(t.Painter.Paint(styles[i], cell))
+		if i < last {
+			builder.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+			builder.WriteString(columnGap)
+		}
+	}
+	return builder.String()
+}
+
+func wrapRow(row []string, column int, width int) [][]string {
+	lines := [][]string{}
+
+	for i, text := range wrap(row[column], width) {
+		line := make([]string, len(row))
+		if i == 0 {
+			copy(line, row)
+		}
+		line[column] = text
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func wrap(text string, width int) []string {
+	lines := []string{}
+	current := []rune{}
+
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+
+		for len(runes) > width {
+			if len(current) > 0 {
+				lines = append(lines, string(current))
+				current = nil
+			}
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+
+		switch {
+		case len(current) == 0:
+			current = runes
+		case len(current)+1+len(runes) <= width:
+			current = append(append(current, ' '), runes...)
+		default:
+			lines = append(lines, string(current))
+			current = runes
+		}
+	}
+
+	if len(current) > 0 || len(lines) == 0 {
+		lines = append(lines, string(current))
+	}
+	return lines
+}
+
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
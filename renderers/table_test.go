@@ -0,0 +1,120 @@
+package renderers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	asserts := assert.New(t)
+
+	createTasks := func() []*models.Task {
+		due := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+		tasks := []*models.Task{createTask(1, models.TODO), createTask(12, models.DONE)}
+		tasks[0].Description = "Buy groceries and cook dinner for the whole family"
+		tasks[0].Priority = models.HIGH
+		tasks[1].Due = &due
+		return tasks
+	}
+
+	render := func(table *Table, tasks []*models.Task) string {
+		var buffer bytes.Buffer
+		err := table.Render(&buffer, tasks)
+		asserts.Nil(err)
+		return buffer.String()
+	}
+
+	t.Run("✅ Should align the selected columns under a header", func(t *testing.T) {
+		result := render(&Table{Columns: []string{"id", "status", "due", "description"}}, createTasks())
+
+		asserts.Equal(""+
+			"ID  STATUS  DUE         DESCRIPTION\n"+
+			"1   To do               Buy groceries and cook dinner for the whole family\n"+
+			"12  Done    2024-09-01  Task 12\n", result)
+	})
+
+	t.Run("✅ Should truncate the description to the width", func(t *testing.T) {
+		result := render(&Table{Columns: []string{"id", "description"}, Width: 20}, createTasks())
+
+		asserts.Equal(""+
+			"ID  DESCRIPTION\n"+
+			"1   Buy groceries a…\n"+
+			"12  Task 12\n", result)
+	})
+
+	t.Run("✅ Should wrap the description to the width", func(t *testing.T) {
+		result := render(&Table{Columns: []string{"description", "id"}, Width: 24, Wrap: true}, createTasks()[:1])
+
+		asserts.Equal(""+
+			"DESCRIPTION           ID\n"+
+			"Buy groceries and     1\n"+
+			"cook dinner for the\n"+
+			"whole family\n", result)
+	})
+
+	t.Run("✅ Should use the configured date format", func(t *testing.T) {
+		result := render(&Table{Columns: []string{"id", "created"}, DateFormat: "02/01/2006"}, createTasks()[:1])
+
+		asserts.Equal("ID  CREATED\n1   24/08/2024\n", result)
+	})
+
+	t.Run("✅ Should color statuses and overdue dates without shifting columns", func(t *testing.T) {
+		painter, err := NewPainter("always", Theme{"done": "bright-green"}, nil)
+		tasks := createTasks()
+		tasks[1].Status = models.TODO
+		now := time.Date(2024, 9, 2, 10, 0, 0, 0, time.UTC)
+		result := render(&Table{Columns: []string{"status", "due", "id"}, Painter: painter, Now: now}, tasks)
+
+		asserts.Nil(err)
+		asserts.Equal(""+
+			"\x1b[1mSTATUS\x1b[0m  \x1b[1mDUE\x1b[0m         \x1b[1mID\x1b[0m\n"+
+			"\x1b[33mTo do\x1b[0m               1\n"+
+			"\x1b[33mTo do\x1b[0m   \x1b[1;31m2024-09-01\x1b[0m  12\n", result)
+	})
+
+	t.Run("✅ Should only disable auto colors when NO_COLOR is not empty", func(t *testing.T) {
+		device, err := os.Open("/dev/zero")
+		if err != nil {
+			t.Skip("no character device to stand in for a terminal")
+		}
+		defer device.Close()
+
+		t.Setenv("NO_COLOR", "")
+		painter, _ := NewPainter("auto", nil, device)
+		asserts.True(painter.Enabled())
+
+		t.Setenv("NO_COLOR", "1")
+		painter, _ = NewPainter("auto", nil, device)
+		asserts.False(painter.Enabled())
+	})
+
+	t.Run("❌ Should reject unknown color modes and styles", func(t *testing.T) {
+		_, modeErr := NewPainter("sometimes", nil, nil)
+		_, styleErr := NewPainter("auto", Theme{"done": "sparkly"}, nil)
+
+		asserts.EqualError(modeErr, `invalid color mode "sometimes", expected: auto, always, never`)
+		asserts.EqualError(styleErr, `invalid theme style for "done": unknown style "sparkly"`)
+	})
+
+	t.Run("❌ Should return an error for an unknown column", func(t *testing.T) {
+		columns, err := ParseColumns("id,size")
+
+		asserts.Nil(columns)
+		asserts.EqualError(err, `unknown column "size"`)
+	})
+}
+
+func createTask(id int, status models.Status) *models.Task {
+	return &models.Task{
+		Id:          id,
+		Description: fmt.Sprintf("Task %d", id),
+		Status:      status,
+		CreatedAt:   time.Date(2024, 8, 24, 0, 0, 0, 0, time.UTC),
+	}
+}
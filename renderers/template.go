@@ -0,0 +1,138 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"task-tracker/models"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+type Template struct {
+	template   *template.Template
+	painter    *Painter
+	dateFormat string
+	humanizer  Humanizer
+	now        func() time.Time
+}
+
+var escapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// NewTemplate parses a text/template executed once per task. Shell escapes
+// \t and \n are expanded and each task ends on its own line.
+func NewTemplate(text string, painter *Painter, dateFormat string, humanizer Humanizer) (*Template, error) {
+	t := &Template{painter: painter, dateFormat: dateFormat, humanizer: humanizer, now: time.Now}
+
+	if dateFormat == "" {
+		t.dateFormat = time.DateOnly
+	}
+
+	text = escapes.Replace(text)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	parsed, err := template.New("format").Option("missingkey=error").Funcs(t.funcs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format: %w", err)
+	}
+
+	t.template = parsed
+	return t, nil
+}
+
+func (t *Template) Render(w io.Writer, tasks []*models.Task) error {
+	for _, task := range tasks {
+		err := t.template.Execute(w, task)
+		if err != nil {
+			return fmt.Errorf("invalid format: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *Template) funcs() template.FuncMap {
+	return template.FuncMap{
+		// date formats a time with the configured format or the given layout: {{date .Due}}, {{date "02/01" .Due}}
+		"date": func(args ...any) (string, error) {
+			layout := t.dateFormat
+			if len(args) == 2 {
+				layout = fmt.Sprint(args[0])
+				args = args[1:]
+			}
+			if len(args) != 1 {
+				return "", fmt.Errorf("date expects an optional layout and a time")
+			}
+			date, ok := toTime(args[0])
+			if !ok {
+				return "", nil
+			}
+			return date.Local().Format(layout), nil
+		},
+		"ago": func(value any) string {
+			date, ok := toTime(value)
+			if !ok {
+				return ""
+			}
+			return t.humanizer.Since(date, t.now())
+		},
+		// due describes a due date as "due in 2 days" or "overdue by 1 day".
+		"due": func(value any) string {
+			date, ok := toTime(value)
+			if !ok {
+				return ""
+			}
+			return t.humanizer.Due(date, t.now())
+		},
+		// pad fills the value to width, a negative width aligns it right.
+		"pad": func(width int, value any) string {
+			text := fmt.Sprint(value)
+			fill := strings.Repeat(" ", max(abs(width)-utf8.RuneCountInString(text), 0))
+			if width < 0 {
+				return fill + text
+			}
+			return text + fill
+		},
+		"trunc": func(width int, value any) string {
+			text := fmt.Sprint(value)
+			if width <= 0 || utf8.RuneCountInString(text) <= width {
+				return text
+			}
+			return truncate(text, width)
+		},
+		// color paints with a theme element or a style: {{.Description | color "bold"}}
+		"color": func(style string, value any) (string, error) {
+			return t.painter.PaintStyle(style, fmt.Sprint(value))
+		},
+		"status": func(status models.Status) string {
+			return t.painter.Status(status)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join": func(separator string, values []string) string {
+			return strings.Join(values, separator)
+		},
+	}
+}
+
+func toTime(value any) (time.Time, bool) {
+	switch date := value.(type) {
+	case time.Time:
+		return date, !date.IsZero()
+	case *time.Time:
+		if date == nil {
+			return time.Time{}, false
+		}
+		return *date, true
+	}
+	return time.Time{}, false
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,62 @@
+package renderers
+
+import (
+	"bytes"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate(t *testing.T) {
+	asserts := assert.New(t)
+
+	render := func(text string, painter *Painter, tasks []*models.Task) (string, error) {
+		template, err := NewTemplate(text, painter, "", Humanizer{})
+		if err != nil {
+			return "", err
+		}
+		template.now = func() time.Time { return time.Date(2024, 8, 27, 0, 0, 0, 0, time.UTC) }
+
+		var buffer bytes.Buffer
+		err = template.Render(&buffer, tasks)
+		return buffer.String(), err
+	}
+
+	t.Run("✅ Should render each task on its own line expanding escapes", func(t *testing.T) {
+		result, err := render(`{{.Id}}\t{{.Status}}\t{{.Description}}`, nil, []*models.Task{createTask(1, models.TODO), createTask(2, models.DONE)})
+
+		asserts.Nil(err)
+		asserts.Equal("1\tTo do\tTask 1\n2\tDone\tTask 2\n", result)
+	})
+
+	t.Run("✅ Should format, pad and humanize values", func(t *testing.T) {
+		task := createTask(7, models.TODO)
+		task.Tags = []string{"a", "b"}
+		result, err := render(`{{pad -3 .Id}}|{{pad 8 .Description}}|{{date .CreatedAt}}|{{date "02/01" .Due}}|{{ago .CreatedAt}}|{{join "+" .Tags}}|{{.Description | trunc 3}}`, nil, []*models.Task{task})
+
+		asserts.Nil(err)
+		asserts.Equal("  7|Task 7  |2024-08-24||3 days ago|a+b|Ta…\n", result)
+	})
+
+	t.Run("✅ Should color with theme elements and styles", func(t *testing.T) {
+		painter, _ := NewPainter("always", nil, nil)
+		result, err := render(`{{status .Status}} {{.Description | color "underline"}}`, painter, []*models.Task{createTask(1, models.DONE)})
+
+		asserts.Nil(err)
+		asserts.Equal("\x1b[32mDone\x1b[0m \x1b[4mTask 1\x1b[0m\n", result)
+	})
+
+	t.Run("❌ Should return an error for an invalid template", func(t *testing.T) {
+		_, err := render(`{{.Id`, nil, nil)
+
+		asserts.ErrorContains(err, "invalid format")
+	})
+
+	t.Run("❌ Should return an error for an unknown style", func(t *testing.T) {
+		_, err := render(`{{.Id | color "sparkly"}}`, nil, []*models.Task{createTask(1, models.DONE)})
+
+		asserts.ErrorContains(err, `unknown style "sparkly"`)
+	})
+}
@@ -0,0 +1,37 @@
+package renderers
+
+import (
+	"os"
+	"strconv"
+)
+
+// IsTerminal reports whether the file is attached to an interactive terminal.
+func IsTerminal(file *os.File) bool {
+	if _, ok := terminalWidth(file); ok {
+		return true
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0 && file.Name() != os.DevNull
+}
+
+// TerminalWidth returns the width of the terminal behind the file, falling
+// back to $COLUMNS and then 80. Output that is not a terminal has no width
+// limit and returns 0.
+func TerminalWidth(file *os.File) int {
+	if !IsTerminal(file) {
+		return 0
+	}
+
+	if width, ok := terminalWidth(file); ok && width > 0 {
+		return width
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
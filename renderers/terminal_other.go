@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package renderers
+
+import "os"
+
+func terminalWidth(file *os.File) (int, bool) {
+	return 0, false
+}
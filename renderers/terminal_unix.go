@@ -0,0 +1,21 @@
+//go:build linux || darwin
+
+package renderers
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func terminalWidth(file *os.File) (int, bool) {
+	size := struct {
+		rows, columns, x, y uint16
+	}{}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0, false
+	}
+	return int(size.columns), true
+}
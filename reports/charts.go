@@ -0,0 +1,271 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"task-tracker/models"
+	"task-tracker/renderers"
+	"time"
+)
+
+const chartHeight = 12
+
+type (
+	// Series is one line or band of a chart, a value per day.
+	Series struct {
+		Name   string    `json:"name"`
+		Values []float64 `json:"values"`
+	}
+
+	Chart struct {
+		Kind   string   `json:"kind"`
+		Days   []string `json:"days"`
+		Series []Series `json:"series"`
+	}
+)
+
+// StatusOn returns the status a task had at the end of day, or false when
+// it did not exist yet. Tasks without history keep their current status.
+func StatusOn(task *models.Task, day time.Time) (models.Status, bool) {
+	end := renderers.StartOfDay(day).AddDate(0, 0, 1)
+	if !task.CreatedAt.Before(end) {
+		return "", false
+	}
+
+	if len(task.History) == 0 {
+		return task.Status, true
+	}
+
+	status := models.TODO
+	for _, change := range task.History {
+		if change.At.Before(end) {
+			status = change.Status
+		}
+	}
+	return status, true
+}
+
+// Burndown charts the unfinished tasks left each day from one day to
+// another, next to the ideal line burning them down evenly.
+func Burndown(tasks []*models.Task, from time.Time, to time.Time) Chart {
+	days := chartDays(from, to)
+	chart := Chart{Kind: "burndown", Days: formatDays(days)}
+	remaining := Series{Name: "remaining"}
+	ideal := Series{Name: "ideal"}
+
+	for _, day := range days {
+		count := 0.0
+		for _, task := range tasks {
+			if status, ok := StatusOn(task, day); ok && status != models.DONE {
+				count++
+			}
+		}
+		remaining.Values = append(remaining.Values, count)
+	}
+
+	for i := range days {
+		start := remaining.Values[0]
+		if len(days) == 1 {
+			ideal.Values = append(ideal.Values, start)
+			continue
+		}
+		ideal.Values = append(ideal.Values, start-start*float64(i)/float64(len(days)-1))
+	}
+
+	chart.Series = []Series{remaining, ideal}
+	return chart
+}
+
+// CumulativeFlow charts how many tasks were in each status every day.
+func CumulativeFlow(tasks []*models.Task, from time.Time, to time.Time) Chart {
+	days := chartDays(from, to)
+	chart := Chart{Kind: "cfd", Days: formatDays(days)}
+
+	for _, status := range []models.Status{models.DONE, models.IN_PROGRESS, models.TODO} {
+		series := Series{Name: renderers.StatusElement(status)}
+		for _, day := range days {
+			count := 0.0
+			for _, task := range tasks {
+				if current, ok := StatusOn(task, day); ok && current == status {
+					count++
+				}
+			}
+			series.Values = append(series.Values, count)
+		}
+		chart.Series = append(chart.Series, series)
+	}
+	return chart
+}
+
+// RenderASCII draws the chart for a terminal, width bounding the plot area.
+func (c *Chart) RenderASCII(w io.Writer, painter *renderers.Painter, width int) error {
+	step := 2
+	if width > 0 && len(c.Days)*step+8 > width {
+		step = 1
+	}
+
+	highest := c.highest()
+	grid := make([][]string, chartHeight)
+	for row := range grid {
+		grid[row] = make([]string, len(c.Days)*step)
+		for column := range grid[row] {
+			grid[row][column] = " "
+		}
+	}
+
+	for day := range c.Days {
+		column := day * step
+		switch c.Kind {
+		case "burndown":
+			if row := scale(c.Series[1].Values[day], highest); grid[row][column] == " " {
+				grid[row][column] = "·"
+			}
+			grid[scale(c.Series[0].Values[day], highest)][column] = painter.Paint("overdue", "●")
+		case "cfd":
+			bands := []string{painter.Paint("done", "█"), painter.Paint("in-progress", "▓"), painter.Paint("todo", "░")}
+			stacked := 0.0
+			bottom := 0
+			for i, series := range c.Series {
+				stacked += series.Values[day]
+				top := int(math.Round(stacked / highest * chartHeight))
+				for row := bottom; row < top && row < chartHeight; row++ {
+					for offset := 0; offset < step; offset++ {
+						grid[row][column+offset] = bands[i]
+					}
+				}
+				bottom = max(bottom, top)
+			}
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(painter.Paint("header", fmt.Sprintf("%s %s → %s", c.title(), c.Days[0], c.Days[len(c.Days)-1])) + "\n")
+
+	for row := chartHeight - 1; row >= 0; row-- {
+		label := ""
+		if row == chartHeight-1 || row == 0 || row == chartHeight/2 {
+			label = fmt.Sprintf("%.0f", highest*float64(row)/float64(chartHeight-1))
+		}
+		builder.WriteString(fmt.Sprintf("%5s ┤", label) + strings.TrimRight(strings.Join(grid[row], ""), " ") + "\n")
+	}
+
+	builder.WriteString("      └" + strings.Repeat("─", len(c.Days)*step) + "\n")
+	first, last := c.Days[0][5:], c.Days[len(c.Days)-1][5:]
+	gap := max(len(c.Days)*step-len(first)-len(last), 1)
+	builder.WriteString("       " + first + strings.Repeat(" ", gap) + last + "\n")
+
+	switch c.Kind {
+	case "burndown":
+		builder.WriteString("       " + painter.Paint("overdue", "●") + " remaining  · ideal\n")
+	case "cfd":
+		builder.WriteString("       " + painter.Paint("done", "█") + " done  " + painter.Paint("in-progress", "▓") + " in progress  " + painter.Paint("todo", "░") + " to do\n")
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// RenderSVG writes the chart as a standalone SVG image.
+func (c *Chart) RenderSVG(w io.Writer) error {
+	const width, height, margin = 640.0, 320.0, 40.0
+	highest := c.highest()
+	plotWidth, plotHeight := width-2*margin, height-2*margin
+
+	x := func(day int) float64 {
+		if len(c.Days) == 1 {
+			return margin
+		}
+		return margin + plotWidth*float64(day)/float64(len(c.Days)-1)
+	}
+	y := func(value float64) float64 {
+		return height - margin - plotHeight*value/highest
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="sans-serif" font-size="11">`+"\n", width, height, width, height))
+	builder.WriteString(`<rect width="100%" height="100%" fill="white"/>` + "\n")
+	builder.WriteString(fmt.Sprintf(`<text x="%.0f" y="20" font-size="14">%s %s → %s</text>`+"\n", margin, c.title(), c.Days[0], c.Days[len(c.Days)-1]))
+	builder.WriteString(fmt.Sprintf(`<path d="M%.0f %.0f V%.0f H%.0f" fill="none" stroke="#333"/>`+"\n", margin, margin, height-margin, width-margin))
+	builder.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f" text-anchor="end">%.0f</text>`+"\n", margin-4, margin+4, highest))
+	builder.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f" text-anchor="end">0</text>`+"\n", margin-4, height-margin+4))
+	builder.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f">%s</text>`+"\n", margin, height-margin+16, c.Days[0]))
+	builder.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.0f" text-anchor="end">%s</text>`+"\n", width-margin, height-margin+16, c.Days[len(c.Days)-1]))
+
+	switch c.Kind {
+	case "burndown":
+		styles := []string{`stroke="#d62728" stroke-width="2"`, `stroke="#999" stroke-dasharray="4 4"`}
+		for i, series := range c.Series {
+			points := []string{}
+			for day, value := range series.Values {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(day), y(value)))
+			}
+			builder.WriteString(fmt.Sprintf(`<polyline points="%s" fill="none" %s><title>%s</title></polyline>`+"\n", strings.Join(points, " "), styles[i], series.Name))
+		}
+	case "cfd":
+		colors := []string{"#2ca02c", "#17becf", "#ffbf00"}
+		lower := make([]float64, len(c.Days))
+		for i, series := range c.Series {
+			upper := make([]float64, len(c.Days))
+			points := []string{}
+			for day, value := range series.Values {
+				upper[day] = lower[day] + value
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(day), y(upper[day])))
+			}
+			for day := len(c.Days) - 1; day >= 0; day-- {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(day), y(lower[day])))
+			}
+			builder.WriteString(fmt.Sprintf(`<polygon points="%s" fill="%s"><title>%s</title></polygon>`+"\n", strings.Join(points, " "), colors[i], series.Name))
+			lower = upper
+		}
+	}
+
+	builder.WriteString("</svg>\n")
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func (c *Chart) title() string {
+	if c.Kind == "cfd" {
+		return "Cumulative flow"
+	}
+	return "Burndown"
+}
+
+// highest is the top of the value axis, never below 1.
+func (c *Chart) highest() float64 {
+	highest := 1.0
+	for day := range c.Days {
+		total := 0.0
+		for _, series := range c.Series {
+			if c.Kind == "cfd" {
+				total += series.Values[day]
+			} else {
+				total = max(total, series.Values[day])
+			}
+		}
+		highest = max(highest, total)
+	}
+	return highest
+}
+
+func scale(value float64, highest float64) int {
+	return min(max(int(math.Round(value/highest*(chartHeight-1))), 0), chartHeight-1)
+}
+
+func chartDays(from time.Time, to time.Time) []time.Time {
+	days := []time.Time{}
+	for day := renderers.StartOfDay(from); !day.After(renderers.StartOfDay(to)); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+func formatDays(days []time.Time) []string {
+	result := []string{}
+	for _, day := range days {
+		result = append(result, day.Format(time.DateOnly))
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package reports
+
+import (
+	"bytes"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharts(t *testing.T) {
+	asserts := assert.New(t)
+	from := time.Date(2024, 8, 20, 9, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 3)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{
+			createTask(1, from.Add(-time.Hour)),
+			createTask(2, from.Add(time.Hour)),
+			createTask(3, from.AddDate(0, 0, 2)),
+		}
+		tasks[0].Status = models.DONE
+		tasks[0].History = []models.StatusChange{
+			{Status: models.IN_PROGRESS, At: from.AddDate(0, 0, 1)},
+			{Status: models.DONE, At: from.AddDate(0, 0, 2)},
+		}
+		tasks[1].Status = models.IN_PROGRESS
+		tasks[1].History = []models.StatusChange{{Status: models.IN_PROGRESS, At: from.AddDate(0, 0, 3)}}
+		return tasks
+	}
+
+	t.Run("✅ Should replay the status a task had on a day", func(t *testing.T) {
+		task := createTasks()[0]
+
+		_, ok := StatusOn(task, from.AddDate(0, 0, -1))
+		asserts.False(ok)
+
+		status, _ := StatusOn(task, from)
+		asserts.Equal(models.TODO, status)
+		status, _ = StatusOn(task, from.AddDate(0, 0, 1))
+		asserts.Equal(models.IN_PROGRESS, status)
+		status, _ = StatusOn(task, to)
+		asserts.Equal(models.DONE, status)
+	})
+
+	t.Run("✅ Should count remaining tasks for the burndown", func(t *testing.T) {
+		chart := Burndown(createTasks(), from, to)
+
+		asserts.Equal([]string{"2024-08-20", "2024-08-21", "2024-08-22", "2024-08-23"}, chart.Days)
+		asserts.Equal([]float64{2, 2, 2, 2}, chart.Series[0].Values)
+		asserts.InDeltaSlice([]float64{2, 4.0 / 3, 2.0 / 3, 0}, chart.Series[1].Values, 1e-9)
+	})
+
+	t.Run("✅ Should count tasks per status for the cumulative flow", func(t *testing.T) {
+		chart := CumulativeFlow(createTasks(), from, to)
+
+		asserts.Equal("done", chart.Series[0].Name)
+		asserts.Equal([]float64{0, 0, 1, 1}, chart.Series[0].Values)
+		asserts.Equal([]float64{0, 1, 0, 1}, chart.Series[1].Values)
+		asserts.Equal([]float64{2, 1, 2, 1}, chart.Series[2].Values)
+	})
+
+	t.Run("✅ Should render ASCII and SVG charts", func(t *testing.T) {
+		chart := CumulativeFlow(createTasks(), from, to)
+
+		var ascii bytes.Buffer
+		asserts.NoError(chart.RenderASCII(&ascii, nil, 80))
+		asserts.Contains(ascii.String(), "Cumulative flow 2024-08-20 → 2024-08-23")
+		asserts.Contains(ascii.String(), "    3 ┤")
+		asserts.Contains(ascii.String(), "█ done")
+
+		var svg bytes.Buffer
+		asserts.NoError(chart.RenderSVG(&svg))
+		asserts.Contains(svg.String(), "<svg xmlns=")
+		asserts.Equal(3, bytes.Count(svg.Bytes(), []byte("<polygon")))
+	})
+}
@@ -0,0 +1,136 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"task-tracker/models"
+	"task-tracker/renderers"
+	"time"
+)
+
+type (
+	Standup struct {
+		Since     string                 `json:"since"`
+		Yesterday []renderers.TaskRecord `json:"yesterday"`
+		Today     []renderers.TaskRecord `json:"today"`
+		Blocked   []BlockedRecord        `json:"blocked"`
+	}
+
+	// BlockedRecord is an open task with the dependencies it waits on.
+	BlockedRecord struct {
+		renderers.TaskRecord
+		BlockedBy []int `json:"blocked_by"`
+	}
+
+	section struct {
+		title string
+		lines []string
+	}
+)
+
+// LastWorkingDay is the weekday before now, skipping back over weekends.
+func LastWorkingDay(now time.Time) time.Time {
+	day := renderers.StartOfDay(now).AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// ComputeStandup collects what was finished since the last working day,
+// what is being worked on or due today, and the open tasks waiting on
+// dependencies that are not done. Dependencies are looked up in all, which
+// may hold more tasks than the ones reported on.
+func ComputeStandup(tasks []*models.Task, all []*models.Task, now time.Time) Standup {
+	since := LastWorkingDay(now)
+	tomorrow := renderers.StartOfDay(now).AddDate(0, 0, 1)
+	standup := Standup{
+		Since:     since.Format(time.DateOnly),
+		Yesterday: []renderers.TaskRecord{},
+		Today:     []renderers.TaskRecord{},
+		Blocked:   []BlockedRecord{},
+	}
+
+	byId := map[int]*models.Task{}
+	for _, task := range all {
+		byId[task.Id] = task
+	}
+
+	for _, task := range tasks {
+		switch {
+		case task.Status == models.DONE:
+			if completed := task.CompletedAt(); completed != nil && !completed.Before(since) && completed.Before(tomorrow) {
+				standup.Yesterday = append(standup.Yesterday, renderers.NewTaskRecord(task))
+			}
+		case len(task.BlockedBy(byId)) > 0:
+			standup.Blocked = append(standup.Blocked, BlockedRecord{renderers.NewTaskRecord(task), task.BlockedBy(byId)})
+		case task.Status == models.IN_PROGRESS || (task.Due != nil && task.Due.Before(tomorrow)):
+			standup.Today = append(standup.Today, renderers.NewTaskRecord(task))
+		}
+	}
+	return standup
+}
+
+// Render writes the standup as plain text, or as Markdown ready to paste.
+func (s *Standup) Render(w io.Writer, painter *renderers.Painter, markdown bool) error {
+	since, err := time.ParseInLocation(time.DateOnly, s.Since, time.Local)
+	if err != nil {
+		return err
+	}
+
+	yesterday := section{title: fmt.Sprintf("Yesterday (%s)", since.Format("Mon 2006-01-02"))}
+	for _, task := range s.Yesterday {
+		yesterday.lines = append(yesterday.lines, fmt.Sprintf("#%d %s", task.Id, task.Description))
+	}
+
+	today := section{title: "Today"}
+	for _, task := range s.Today {
+		status := "(" + task.Status + ")"
+		if !markdown {
+			status = painter.Paint(task.Status, status)
+		}
+		today.lines = append(today.lines, fmt.Sprintf("#%d %s %s", task.Id, task.Description, status))
+	}
+
+	blocked := section{title: "Blocked"}
+	for _, task := range s.Blocked {
+		waits := []string{}
+		for _, id := range task.BlockedBy {
+			waits = append(waits, fmt.Sprintf("#%d", id))
+		}
+		blocked.lines = append(blocked.lines, fmt.Sprintf("#%d %s (waits on %s)", task.Id, task.Description, strings.Join(waits, ", ")))
+	}
+
+	var builder strings.Builder
+	for i, section := range []section{yesterday, today, blocked} {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+
+		if markdown {
+			builder.WriteString("**" + section.title + "**\n")
+		} else {
+			builder.WriteString(painter.Paint("header", section.title) + "\n")
+		}
+
+		if len(section.lines) == 0 {
+			builder.WriteString(bullet(markdown) + "Nothing\n")
+			continue
+		}
+
+		for _, line := range section.lines {
+			builder.WriteString(bullet(markdown) + line + "\n")
+		}
+	}
+
+	_, err = io.WriteString(w, builder.String())
+	return err
+}
+
+func bullet(markdown bool) string {
+	if markdown {
+		return "- "
+	}
+	return "  • "
+}
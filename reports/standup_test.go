@@ -0,0 +1,81 @@
+package reports
+
+import (
+	"bytes"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandup(t *testing.T) {
+	asserts := assert.New(t)
+	monday := time.Date(2024, 8, 26, 9, 0, 0, 0, time.Local)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{
+			createTask(1, monday.AddDate(0, 0, -10)),
+			createTask(2, monday.AddDate(0, 0, -10)),
+			createTask(3, monday.AddDate(0, 0, -5)),
+			createTask(4, monday.AddDate(0, 0, -5)),
+			createTask(5, monday.AddDate(0, 0, -1)),
+		}
+		tasks[0].Status = models.DONE
+		tasks[0].History = []models.StatusChange{{Status: models.DONE, At: monday.AddDate(0, 0, -3)}}
+		tasks[1].Status = models.DONE
+		tasks[1].History = []models.StatusChange{{Status: models.DONE, At: monday.AddDate(0, 0, -5)}}
+		tasks[2].Status = models.IN_PROGRESS
+		tasks[3].DependsOn = []int{1, 3}
+		due := monday.Add(8 * time.Hour)
+		tasks[4].Due = &due
+		return tasks
+	}
+
+	t.Run("✅ Should skip weekends for the last working day", func(t *testing.T) {
+		asserts.Equal(time.Date(2024, 8, 23, 0, 0, 0, 0, time.Local), LastWorkingDay(monday))
+		asserts.Equal(time.Date(2024, 8, 26, 0, 0, 0, 0, time.Local), LastWorkingDay(monday.AddDate(0, 0, 1)))
+	})
+
+	t.Run("✅ Should split tasks into yesterday, today and blocked", func(t *testing.T) {
+		tasks := createTasks()
+		standup := ComputeStandup(tasks, tasks, monday)
+
+		asserts.Equal("2024-08-23", standup.Since)
+		asserts.Len(standup.Yesterday, 1)
+		asserts.Equal(1, standup.Yesterday[0].Id)
+		asserts.Len(standup.Today, 2)
+		asserts.Equal(3, standup.Today[0].Id)
+		asserts.Equal(5, standup.Today[1].Id)
+		asserts.Len(standup.Blocked, 1)
+		asserts.Equal(4, standup.Blocked[0].Id)
+		asserts.Equal([]int{3}, standup.Blocked[0].BlockedBy)
+	})
+
+	t.Run("✅ Should look dependencies up outside the reported tasks", func(t *testing.T) {
+		tasks := createTasks()
+		standup := ComputeStandup(tasks[3:4], tasks, monday)
+		asserts.Len(standup.Blocked, 1)
+
+		tasks[2].Status = models.DONE
+		standup = ComputeStandup(tasks[3:4], tasks, monday)
+		asserts.Empty(standup.Blocked)
+	})
+
+	t.Run("✅ Should render Markdown", func(t *testing.T) {
+		tasks := createTasks()
+		standup := ComputeStandup(tasks, tasks, monday)
+
+		var buffer bytes.Buffer
+		asserts.NoError(standup.Render(&buffer, nil, true))
+		asserts.Equal("**Yesterday (Fri 2024-08-23)**\n"+
+			"- #1 Task 1\n"+
+			"\n"+
+			"**Today**\n"+
+			"- #3 Task 3 (in-progress)\n"+
+			"- #5 Task 5 (todo)\n"+
+			"\n"+
+			"**Blocked**\n"+
+			"- #4 Task 4 (waits on #3)\n", buffer.String())
+	})
+}
@@ -0,0 +1,226 @@
+package reports
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"task-tracker/models"
+	"task-tracker/renderers"
+	"time"
+)
+
+type (
+	Stats struct {
+		Total      int           `json:"total"`
+		ByStatus   []StatusCount `json:"by_status"`
+		PerDay     []Bucket      `json:"completed_per_day"`
+		PerWeek    []Bucket      `json:"completed_per_week"`
+		LeadTime   Average       `json:"lead_time"`
+		CycleTime  Average       `json:"cycle_time"`
+		OldestOpen []OpenTask    `json:"oldest_open"`
+	}
+
+	StatusCount struct {
+		Status models.Status `json:"-"`
+		Key    string        `json:"status"`
+		Count  int           `json:"count"`
+	}
+
+	Bucket struct {
+		Start string `json:"start"`
+		Count int    `json:"count"`
+	}
+
+	// Average is a mean duration over the tasks whose history records both ends.
+	Average struct {
+		Seconds float64 `json:"seconds"`
+		Tasks   int     `json:"tasks"`
+	}
+
+	OpenTask struct {
+		Id          int           `json:"id"`
+		Description string        `json:"description"`
+		Status      models.Status `json:"-"`
+		Key         string        `json:"status"`
+		CreatedAt   time.Time     `json:"created_at"`
+	}
+)
+
+const oldestOpenTasks = 5
+
+// ComputeStats summarizes tasks as of now, bucketing completions over the
+// last days and weeks.
+func ComputeStats(tasks []*models.Task, now time.Time, days int, weeks int) Stats {
+	stats := Stats{Total: len(tasks)}
+
+	statuses := []models.Status{models.TODO, models.IN_PROGRESS, models.DONE}
+	for _, task := range tasks {
+		if !slices.Contains(statuses, task.Status) {
+			statuses = append(statuses, task.Status)
+		}
+	}
+	for _, status := range statuses {
+		count := len(models.Query{Filter: func(task *models.Task) bool {
+			return task.Status == status
+		}}.Apply(tasks))
+		stats.ByStatus = append(stats.ByStatus, StatusCount{status, renderers.StatusElement(status), count})
+	}
+
+	today := renderers.StartOfDay(now)
+	week := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	stats.PerDay = buckets(tasks, today.AddDate(0, 0, -(days-1)), days, 1)
+	stats.PerWeek = buckets(tasks, week.AddDate(0, 0, -7*(weeks-1)), weeks, 7)
+
+	lead, cycle := []time.Duration{}, []time.Duration{}
+	for _, task := range tasks {
+		completed := task.CompletedAt()
+		if completed == nil {
+			continue
+		}
+		lead = append(lead, completed.Sub(task.CreatedAt))
+		if started := task.StartedAt(); started != nil && started.Before(*completed) {
+			cycle = append(cycle, completed.Sub(*started))
+		}
+	}
+	stats.LeadTime = average(lead)
+	stats.CycleTime = average(cycle)
+
+	open := models.Query{
+		Filter: func(task *models.Task) bool { return task.Status != models.DONE },
+		Sort:   func(a, b *models.Task) int { return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.Id, b.Id)) },
+		Limit:  oldestOpenTasks,
+	}.Apply(tasks)
+	stats.OldestOpen = []OpenTask{}
+	for _, task := range open {
+		stats.OldestOpen = append(stats.OldestOpen, OpenTask{task.Id, task.Description, task.Status, renderers.StatusElement(task.Status), task.CreatedAt})
+	}
+
+	return stats
+}
+
+func (s *Stats) Render(w io.Writer, painter *renderers.Painter, now time.Time) error {
+	var builder strings.Builder
+
+	builder.WriteString(painter.Paint("header", fmt.Sprintf("Tasks: %d", s.Total)) + "\n")
+	for _, count := range s.ByStatus {
+		label := fmt.Sprintf("%-14s", count.Status)
+		builder.WriteString("  " + painter.Paint(count.Key, label) + fmt.Sprintf("%4d", count.Count) + "\n")
+	}
+
+	builder.WriteString(painter.Paint("header", "Completed") + "\n")
+	for _, row := range []struct {
+		label   string
+		buckets []Bucket
+	}{
+		{fmt.Sprintf("Last %d days", len(s.PerDay)), s.PerDay},
+		{fmt.Sprintf("Last %d weeks", len(s.PerWeek)), s.PerWeek},
+	} {
+		counts, total := []int{}, 0
+		for _, bucket := range row.buckets {
+			counts = append(counts, bucket.Count)
+			total += bucket.Count
+		}
+		builder.WriteString(fmt.Sprintf("  %-14s%s  %d total%s\n", row.label, renderers.Sparkline(counts), total, trend(counts)))
+	}
+
+	builder.WriteString(fmt.Sprintf("Lead time (created → done):        %s\n", s.LeadTime))
+	builder.WriteString(fmt.Sprintf("Cycle time (in progress → done):   %s\n", s.CycleTime))
+
+	builder.WriteString(painter.Paint("header", "Oldest open tasks") + "\n")
+	if len(s.OldestOpen) == 0 {
+		builder.WriteString("  " + models.NoTaskString + "\n")
+	}
+	for _, task := range s.OldestOpen {
+		builder.WriteString(fmt.Sprintf("  #%d %s, %s, created %s\n", task.Id, task.Description, task.Status, renderers.Humanize(task.CreatedAt, now)))
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func (a Average) String() string {
+	if a.Tasks == 0 {
+		return "no completed tasks with history yet"
+	}
+
+	plural := "s"
+	if a.Tasks == 1 {
+		plural = ""
+	}
+	return fmt.Sprintf("%s average over %d task%s", FormatDuration(time.Duration(a.Seconds*float64(time.Second))), a.Tasks, plural)
+}
+
+// FormatDuration prints the two largest units of a duration, like "2d 4h".
+func FormatDuration(duration time.Duration) string {
+	units := []struct {
+		size time.Duration
+		name string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}}
+
+	parts := []string{}
+	for _, unit := range units {
+		if duration >= unit.size && len(parts) < 2 {
+			parts = append(parts, fmt.Sprintf("%d%s", duration/unit.size, unit.name))
+			duration %= unit.size
+		} else if len(parts) > 0 {
+			break
+		}
+	}
+
+	if len(parts) == 0 {
+		return "<1m"
+	}
+	return strings.Join(parts, " ")
+}
+
+// buckets counts completions in count consecutive periods of size days.
+func buckets(tasks []*models.Task, start time.Time, count int, size int) []Bucket {
+	result := []Bucket{}
+	for i := 0; i < count; i++ {
+		result = append(result, Bucket{Start: start.AddDate(0, 0, i*size).Format(time.DateOnly)})
+	}
+
+	for _, task := range tasks {
+		completed := task.CompletedAt()
+		if completed == nil {
+			continue
+		}
+		for i := range result {
+			from := start.AddDate(0, 0, i*size)
+			if !completed.Before(from) && completed.Before(from.AddDate(0, 0, size)) {
+				result[i].Count++
+			}
+		}
+	}
+	return result
+}
+
+func average(durations []time.Duration) Average {
+	if len(durations) == 0 {
+		return Average{}
+	}
+
+	total := 0.0
+	for _, duration := range durations {
+		total += duration.Seconds()
+	}
+	return Average{Seconds: total / float64(len(durations)), Tasks: len(durations)}
+}
+
+// trend compares the last period with the one before it.
+func trend(counts []int) string {
+	if len(counts) < 2 {
+		return ""
+	}
+
+	last, previous := counts[len(counts)-1], counts[len(counts)-2]
+	switch {
+	case last > previous:
+		return fmt.Sprintf(", ↑ %d vs previous", last-previous)
+	case last < previous:
+		return fmt.Sprintf(", ↓ %d vs previous", previous-last)
+	}
+	return ", → same as previous"
+}
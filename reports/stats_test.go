@@ -0,0 +1,95 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	asserts := assert.New(t)
+	now := time.Date(2024, 8, 28, 12, 0, 0, 0, time.Local)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{
+			createTask(1, now.AddDate(0, 0, -10)),
+			createTask(2, now.AddDate(0, 0, -3)),
+			createTask(3, now.AddDate(0, 0, -2)),
+			createTask(4, now.AddDate(0, 0, -1)),
+		}
+		tasks[0].Status = models.DONE
+		tasks[0].History = []models.StatusChange{
+			{Status: models.IN_PROGRESS, At: now.AddDate(0, 0, -4)},
+			{Status: models.DONE, At: now.AddDate(0, 0, -2)},
+		}
+		tasks[1].Status = models.DONE
+		tasks[1].History = []models.StatusChange{{Status: models.DONE, At: now.Add(-time.Hour)}}
+		tasks[2].Status = models.IN_PROGRESS
+		tasks[2].History = []models.StatusChange{{Status: models.IN_PROGRESS, At: now.AddDate(0, 0, -1)}}
+		return tasks
+	}
+
+	t.Run("✅ Should count tasks per status", func(t *testing.T) {
+		stats := ComputeStats(createTasks(), now, 7, 2)
+
+		asserts.Equal(4, stats.Total)
+		asserts.Equal([]StatusCount{
+			{models.TODO, "todo", 1},
+			{models.IN_PROGRESS, "in-progress", 1},
+			{models.DONE, "done", 2},
+		}, stats.ByStatus)
+	})
+
+	t.Run("✅ Should bucket completions per day and week", func(t *testing.T) {
+		stats := ComputeStats(createTasks(), now, 3, 2)
+
+		asserts.Equal([]Bucket{{"2024-08-26", 1}, {"2024-08-27", 0}, {"2024-08-28", 1}}, stats.PerDay)
+		asserts.Equal([]Bucket{{"2024-08-19", 0}, {"2024-08-26", 2}}, stats.PerWeek)
+	})
+
+	t.Run("✅ Should average lead and cycle times from the history", func(t *testing.T) {
+		stats := ComputeStats(createTasks(), now, 7, 2)
+
+		asserts.Equal(2, stats.LeadTime.Tasks)
+		asserts.Equal("5d 11h average over 2 tasks", stats.LeadTime.String())
+		asserts.Equal("2d average over 1 task", stats.CycleTime.String())
+	})
+
+	t.Run("✅ Should list the oldest open tasks first", func(t *testing.T) {
+		stats := ComputeStats(createTasks(), now, 7, 2)
+
+		asserts.Len(stats.OldestOpen, 2)
+		asserts.Equal(3, stats.OldestOpen[0].Id)
+		asserts.Equal(4, stats.OldestOpen[1].Id)
+	})
+
+	t.Run("✅ Should render the summary with sparklines", func(t *testing.T) {
+		stats := ComputeStats(createTasks(), now, 3, 2)
+
+		var buffer bytes.Buffer
+		err := stats.Render(&buffer, nil, now)
+
+		asserts.Nil(err)
+		asserts.Contains(buffer.String(), "  Last 3 days   █▁█  2 total, ↑ 1 vs previous\n")
+		asserts.Contains(buffer.String(), "  #3 Task 3, In progress, created 2 days ago\n")
+	})
+
+	t.Run("✅ Should format durations with two units", func(t *testing.T) {
+		asserts.Equal("<1m", FormatDuration(30*time.Second))
+		asserts.Equal("3h 20m", FormatDuration(200*time.Minute))
+		asserts.Equal("2d", FormatDuration(48*time.Hour+5*time.Minute))
+	})
+}
+
+func createTask(id int, createdAt time.Time) *models.Task {
+	return &models.Task{
+		Id:          id,
+		Description: fmt.Sprintf("Task %d", id),
+		Status:      models.TODO,
+		CreatedAt:   createdAt,
+	}
+}
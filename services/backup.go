@@ -0,0 +1,97 @@
+package services
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"task-tracker/models"
+	"task-tracker/renderers"
+	"task-tracker/stores"
+	"time"
+)
+
+func (c *commandLine) fileStore() stores.FileStore {
+	store, ok := c.store.(stores.FileStore)
+	if !ok {
+		exitOnError(fmt.Errorf("this store cannot be backed up"))
+	}
+	return store
+}
+
+func (c *commandLine) backupCommand() {
+	backupSubCommand := flag.NewFlagSet("backup", flag.ExitOnError)
+	backupSubCommand.Parse(c.args)
+
+	files := c.fileStore().Files()
+	now := time.Now()
+	path := backupSubCommand.Arg(0)
+
+	if info, err := os.Stat(path); path == "" || (err == nil && info.IsDir()) {
+		path = filepath.Join(path, stores.BackupName(files, now))
+	}
+
+	file, err := os.Create(path)
+	exitOnError(err)
+	defer file.Close()
+
+	manifest, err := stores.WriteBackup(file, files, now)
+	exitOnError(err)
+
+	c.printManifest("backup", path, manifest, fmt.Sprintf("Backup of %s written to %s", strings.Join(files, ", "), path))
+}
+
+func (c *commandLine) restoreCommand() {
+	restoreSubCommand := flag.NewFlagSet("restore", flag.ExitOnError)
+	restoreSubCommand.Parse(c.args)
+
+	if restoreSubCommand.NArg() != 1 {
+		exitOnError(fmt.Errorf("please provide the backup archive to restore"))
+	}
+	path := restoreSubCommand.Arg(0)
+	files := c.fileStore().Files()
+
+	archive, err := os.Open(path)
+	exitOnError(err)
+	defer archive.Close()
+
+	_, _, err = stores.ReadBackup(archive)
+	exitOnError(err)
+
+	previous, err := stores.RotateBackup(files, stores.BackupDir(files[0]), stores.KeepBackups, time.Now())
+	exitOnError(err)
+
+	_, err = archive.Seek(0, 0)
+	exitOnError(err)
+	manifest, err := stores.RestoreBackup(archive, files)
+	exitOnError(err)
+
+	// Roll back when the restored files do not load.
+	if _, err := c.store.Query(models.Query{}); err != nil {
+		rollback, openErr := os.Open(previous)
+		exitOnError(openErr)
+		defer rollback.Close()
+
+		_, rollbackErr := stores.RestoreBackup(rollback, files)
+		exitOnError(rollbackErr)
+		exitOnError(fmt.Errorf("restored tasks do not load, kept the previous ones: %w", err))
+	}
+
+	c.printManifest("restore", path, manifest, fmt.Sprintf("Restored %s from %s, the previous tasks were saved to %s", strings.Join(files, ", "), path, previous))
+}
+
+func (c *commandLine) printManifest(action string, path string, manifest *stores.BackupManifest, message string) {
+	if c.output == renderers.TEXT {
+		fmt.Println(message)
+		return
+	}
+
+	err := renderers.WriteDocument(os.Stdout, c.output, struct {
+		SchemaVersion int    `json:"schema_version"`
+		Action        string `json:"action"`
+		Archive       string `json:"archive"`
+		*stores.BackupManifest
+	}{renderers.SchemaVersion, action, path, manifest})
+	exitOnError(err)
+}
@@ -4,8 +4,15 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"task-tracker/configs"
+	"task-tracker/filters"
 	"task-tracker/models"
+	"task-tracker/renderers"
+	"task-tracker/reports"
 	"task-tracker/stores"
+	"time"
 )
 
 type (
@@ -14,67 +21,204 @@ type (
 	}
 
 	commandLine struct {
-		store models.TaskStore
+		store     models.TaskStore
+		open      func(spec string) (models.TaskStore, error)
+		config    *configs.Config
+		painter   *renderers.Painter
+		humanizer renderers.Humanizer
+		dates     string
+		output    renderers.Format
+		args      []string
 	}
 )
 
-func NewCommandLine(store *stores.JsonTaskStore) CommandLine {
+// NewCommandLine runs commands against the store opened from the --store
+// flag, the configured store or tasks.json.
+func NewCommandLine(open func(spec string) (models.TaskStore, error), config *configs.Config) CommandLine {
 	return &commandLine{
-		store: store,
-	}
-}
-
-func (c *commandLine) selectList(listType string) {
-	tasks := c.store
-
-	switch listType {
-	case "todo":
-		tasks.PrintTodo()
-	case "in-progress":
-		tasks.PrintInProgress()
-	case "done":
-		tasks.PrintDone()
-	default:
-		tasks.PrintAll()
+		open:   open,
+		config: config,
 	}
 }
 
 func (c *commandLine) addTaskCommand() {
 	addTaskSubCommand := flag.NewFlagSet("add", flag.ExitOnError)
-	addTaskSubCommand.Parse(os.Args[2:])
 	addDescription := addTaskSubCommand.String("description", "", "Description of the task")
-	c.store.AddTask(&models.Task{
+	addNotes := addTaskSubCommand.String("notes", "", "Notes of the task")
+	addPriority := addTaskSubCommand.String("priority", "", "Priority of the task: low, medium, high")
+	addTags := addTaskSubCommand.String("tags", "", "Comma separated tags of the task")
+	addDue := addTaskSubCommand.String("due", "", "Due date of the task (YYYY-MM-DD)")
+	addProject := addTaskSubCommand.String("project", "", "Project of the task")
+	addAssignee := addTaskSubCommand.String("assignee", "", "Who the task is assigned to")
+	addDepends := addTaskSubCommand.String("depends", "", "Comma separated IDs of the tasks this one waits on")
+	addTaskSubCommand.Parse(c.args)
+
+	task := &models.Task{
 		Description: *addDescription,
-	})
+		Notes:       strings.TrimSpace(*addNotes),
+		Project:     strings.TrimSpace(*addProject),
+		Assignee:    strings.TrimSpace(*addAssignee),
+	}
+
+	if task.Description == "" {
+		task.Description = strings.Join(addTaskSubCommand.Args(), " ")
+	}
+
+	if *addPriority != "" {
+		priority, err := models.ParsePriority(*addPriority)
+		exitOnError(err)
+		task.Priority = priority
+	}
+
+	for _, tag := range strings.Split(*addTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	if *addDue != "" {
+		due, err := filters.ParseDate(*addDue)
+		exitOnError(err)
+		task.Due = &due
+	}
+
+	if *addDepends != "" {
+		dependsOn, err := models.ParseDependencies(*addDepends)
+		exitOnError(err)
+		task.DependsOn = c.checkDependencies(0, dependsOn)
+	}
+
+	added, err := c.store.AddTask(task)
+	exitOnError(err)
+
+	c.printAffected("added", fmt.Sprintf("Task added successfully (ID: %d)", added.Id), added)
 }
 
 func (c *commandLine) updateTaskCommand() {
 	updateTaskSubCommand := flag.NewFlagSet("update", flag.ExitOnError)
 	updateDescription := updateTaskSubCommand.String("description", "", "Description of the task")
-	updateId := updateTaskSubCommand.Int("id", 0, "ID of the task")
-	updateTaskSubCommand.Parse(os.Args[2:])
-	c.store.UpdateTask(*updateId, *updateDescription)
+	updateId := updateTaskSubCommand.String("id", "", taskReferenceUsage)
+	updateNotes := updateTaskSubCommand.String("notes", "", "Notes of the task, empty to clear them")
+	updateProject := updateTaskSubCommand.String("project", "", "Project of the task, empty to clear it")
+	updateAssignee := updateTaskSubCommand.String("assignee", "", "Who the task is assigned to, empty to clear it")
+	updateDepends := updateTaskSubCommand.String("depends", "", "Comma separated IDs of the tasks this one waits on, empty to clear them")
+	updateTaskSubCommand.Parse(c.args)
+
+	set := map[string]bool{}
+	updateTaskSubCommand.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	args := updateTaskSubCommand.Args()
+	if *updateId == "" && len(args) > 0 {
+		*updateId, args = args[0], args[1:]
+	}
+
+	if *updateDescription == "" {
+		*updateDescription = strings.Join(args, " ")
+	}
+
+	id := c.resolveTask(*updateId)
+
+	fields := set["notes"] || set["project"] || set["assignee"] || set["depends"]
+	if *updateDescription != "" || !fields {
+		err := c.store.UpdateTask(id, *updateDescription)
+		exitOnError(err)
+	}
+
+	if fields {
+		task := c.findTask(id)
+		if set["notes"] {
+			task.Notes = strings.TrimSpace(*updateNotes)
+		}
+		if set["project"] {
+			task.Project = strings.TrimSpace(*updateProject)
+		}
+		if set["assignee"] {
+			task.Assignee = strings.TrimSpace(*updateAssignee)
+		}
+		if set["depends"] {
+			dependsOn, err := models.ParseDependencies(*updateDepends)
+			exitOnError(err)
+			task.DependsOn = c.checkDependencies(id, dependsOn)
+		}
+		updatedTime := time.Now()
+		task.UpdatedAt = &updatedTime
+
+		_, err := c.store.ImportTasks([]*models.Task{task})
+		exitOnError(err)
+	}
+
+	c.printAffected("updated", fmt.Sprintf("Task updated successfully (ID: %d)", id), c.findTask(id))
 }
 
 func (c *commandLine) deleteTaskCommand() {
 	deleteTaskSubCommand := flag.NewFlagSet("delete", flag.ExitOnError)
-	deleteId := deleteTaskSubCommand.Int("id", 0, "ID of the task")
-	deleteTaskSubCommand.Parse(os.Args[2:])
-	c.store.RemoveTask(*deleteId)
+	deleteId := deleteTaskSubCommand.String("id", "", taskReferenceUsage)
+	deleteTaskSubCommand.Parse(c.args)
+
+	removed, err := c.store.RemoveTask(c.resolveTask(taskReference(*deleteId, deleteTaskSubCommand.Args())))
+	exitOnError(err)
+
+	c.printAffected("deleted", fmt.Sprintf("Task deleted successfully (ID: %d)", removed.Id), removed)
 }
 
 func (c *commandLine) markAsTaskDoneCommand() {
 	markAsTaskDoneSubCommand := flag.NewFlagSet("mark-done", flag.ExitOnError)
-	markAsTaskDoneId := markAsTaskDoneSubCommand.Int("id", 0, "ID of the task")
-	markAsTaskDoneSubCommand.Parse(os.Args[2:])
-	c.store.MarkDone(*markAsTaskDoneId)
+	markAsTaskDoneId := markAsTaskDoneSubCommand.String("id", "", taskReferenceUsage)
+	markAsTaskDoneSubCommand.Parse(c.args)
+
+	id := c.resolveTask(taskReference(*markAsTaskDoneId, markAsTaskDoneSubCommand.Args()))
+	err := c.store.MarkDone(id)
+	exitOnError(err)
+
+	c.printAffected("marked-done", fmt.Sprintf("Task marked as done (ID: %d)", id), c.findTask(id))
 }
 
 func (c *commandLine) markAsTaskInProgressCommand() {
 	markAsTaskInProgressSubCommand := flag.NewFlagSet("mark-in-progress", flag.ExitOnError)
-	markAsTaskInProgressId := markAsTaskInProgressSubCommand.Int("id", 0, "ID of the task")
-	markAsTaskInProgressSubCommand.Parse(os.Args[2:])
-	c.store.MarkInProgress(*markAsTaskInProgressId)
+	markAsTaskInProgressId := markAsTaskInProgressSubCommand.String("id", "", taskReferenceUsage)
+	markAsTaskInProgressSubCommand.Parse(c.args)
+
+	id := c.resolveTask(taskReference(*markAsTaskInProgressId, markAsTaskInProgressSubCommand.Args()))
+	err := c.store.MarkInProgress(id)
+	exitOnError(err)
+
+	c.printAffected("marked-in-progress", fmt.Sprintf("Task marked as in progress (ID: %d)", id), c.findTask(id))
+}
+
+func (c *commandLine) showCommand() {
+	showSubCommand := flag.NewFlagSet("show", flag.ExitOnError)
+	showId := showSubCommand.String("id", "", taskReferenceUsage)
+	showSubCommand.Parse(c.args)
+
+	task := c.findTask(c.resolveTask(taskReference(*showId, showSubCommand.Args())))
+
+	if c.output != renderers.TEXT {
+		err := renderers.WriteRecords(os.Stdout, c.output, "", "tasks", renderers.TaskRecords([]*models.Task{task}))
+		exitOnError(err)
+		return
+	}
+
+	err := c.table("").RenderDetail(os.Stdout, task)
+	exitOnError(err)
+}
+
+func (c *commandLine) historyCommand() {
+	historySubCommand := flag.NewFlagSet("history", flag.ExitOnError)
+	historyId := historySubCommand.String("id", "", taskReferenceUsage)
+	historySubCommand.Parse(c.args)
+
+	task := c.findTask(c.resolveTask(taskReference(*historyId, historySubCommand.Args())))
+
+	if c.output != renderers.TEXT {
+		err := renderers.WriteRecords(os.Stdout, c.output, "", "history", renderers.HistoryRecords(task))
+		exitOnError(err)
+		return
+	}
+
+	err := c.table("").RenderHistory(os.Stdout, task)
+	exitOnError(err)
 }
 
 func (c *commandLine) listCommand() {
@@ -82,36 +226,615 @@ func (c *commandLine) listCommand() {
 	listTodo := listTaskSubCommand.Bool("todo", false, "List tasks in todo status")
 	listInProgress := listTaskSubCommand.Bool("in-progress", false, "List tasks in in-progress status")
 	listDone := listTaskSubCommand.Bool("done", false, "List tasks in done status")
-	listTaskSubCommand.Parse(os.Args[2:])
-	if len(os.Args) < 3 {
-		c.store.PrintAll()
+	listSort := listTaskSubCommand.String("sort", "", "Comma separated sort fields, prefix with - for descending: created,-priority,due")
+	listLimit := listTaskSubCommand.Int("limit", 0, "Maximum number of tasks to list")
+	listOffset := listTaskSubCommand.Int("offset", 0, "Number of matching tasks to skip")
+	listColumns := listTaskSubCommand.String("columns", "", "Comma separated columns: id,description,status,priority,tags,project,assignee,due,created,updated")
+	listWrap := listTaskSubCommand.Bool("wrap", false, "Wrap long descriptions instead of truncating them")
+	listFormat := listTaskSubCommand.String("format", "", "Go text/template applied to each task, or the name of a format from the config")
+	listAsOf := listTaskSubCommand.String("as-of", "", "List the tasks as they were at the end of a day, needs the events store")
+	listTaskSubCommand.Parse(c.args)
+
+	args := listTaskSubCommand.Args()
+	statuses := []string{}
+
+	if *listTodo {
+		statuses = append(statuses, "todo")
+	}
+	if *listInProgress {
+		statuses = append(statuses, "in-progress")
+	}
+	if *listDone {
+		statuses = append(statuses, "done")
+	}
+
+	if len(statuses) > 0 {
+		args = append(args, "status:"+strings.Join(statuses, ","))
+	}
+
+	if len(args) == 1 && (args[0] == "todo" || args[0] == "in-progress" || args[0] == "done") {
+		args[0] = "@" + args[0]
+	}
+
+	query := filters.JoinArgs(args)
+
+	if len(args) > 0 && strings.HasPrefix(args[0], "@") {
+		view, ok := c.config.View(args[0][1:])
+		if !ok {
+			exitOnError(fmt.Errorf("view %q not found", args[0][1:]))
+		}
+
+		query = "(" + view.Query + ") " + filters.JoinArgs(args[1:])
+		if *listSort == "" {
+			*listSort = view.Sort
+		}
+	}
+
+	if *listLimit < 0 || *listOffset < 0 {
+		exitOnError(fmt.Errorf("limit and offset must not be negative"))
+	}
+
+	node, err := filters.Parse(query)
+	exitOnError(err)
+
+	sort, err := filters.ParseSort(*listSort)
+	exitOnError(err)
+
+	taskQuery := models.Query{
+		Filter: filters.Filter(node),
+		Where:  filters.Where(node),
+		Sort:   sort,
+		Limit:  *listLimit,
+		Offset: *listOffset,
+	}
+
+	var tasks []*models.Task
+	if *listAsOf != "" {
+		tasks, err = c.tasksAsOf(*listAsOf, taskQuery)
+	} else {
+		tasks, err = c.store.Query(taskQuery)
+	}
+	exitOnError(err)
+
+	if *listFormat != "" {
+		c.printFormatted(*listFormat, tasks)
 		return
 	}
 
-	if len(os.Args) > 3 {
-		fmt.Println("It is not possible to list tasks by more than one status at the same time")
+	table := c.table(*listColumns)
+	table.Wrap = *listWrap
+	c.printTasks(table, tasks)
+}
+
+// tasksAsOf replays the event log up to the end of day to query the tasks
+// as they were then.
+func (c *commandLine) tasksAsOf(day string, query models.Query) ([]*models.Task, error) {
+	store, ok := c.store.(*stores.EventTaskStore)
+	if !ok {
+		return nil, fmt.Errorf("-as-of needs the events store, such as --store events:tasks.jsonl")
+	}
+
+	date, err := filters.ParseDate(day)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := store.AsOf(date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return query.Apply(tasks), nil
+}
+
+func (c *commandLine) printFormatted(format string, tasks []*models.Task) {
+	if c.output != renderers.TEXT {
+		exitOnError(fmt.Errorf("-format can not be combined with -output %s", c.output))
+	}
+
+	if named, ok := c.config.Formats[format]; ok {
+		format = named
+	} else if !strings.Contains(format, "{{") {
+		exitOnError(fmt.Errorf("format %q not found", format))
+	}
+
+	template, err := renderers.NewTemplate(format, c.painter, c.config.DateFormat, c.humanizer)
+	exitOnError(err)
+
+	err = template.Render(os.Stdout, tasks)
+	exitOnError(err)
+}
+
+func (c *commandLine) boardCommand() {
+	boardSubCommand := flag.NewFlagSet("board", flag.ExitOnError)
+	boardGroup := boardSubCommand.String("group", "", "Split the board into swimlanes by: "+strings.Join(renderers.BoardGroups, ", "))
+	boardSubCommand.Parse(c.args)
+
+	node, err := filters.Parse(filters.JoinArgs(boardSubCommand.Args()))
+	exitOnError(err)
+
+	tasks, err := c.store.Query(models.Query{Filter: filters.Filter(node), Where: filters.Where(node)})
+	exitOnError(err)
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteRecords(os.Stdout, c.output, "", "tasks", renderers.TaskRecords(tasks))
+		exitOnError(err)
+		return
+	}
+
+	board := &renderers.Board{
+		Width:   renderers.TerminalWidth(os.Stdout),
+		GroupBy: *boardGroup,
+		Painter: c.painter,
+	}
+
+	err = board.Render(os.Stdout, tasks)
+	exitOnError(err)
+}
+
+func (c *commandLine) agendaCommand() {
+	agendaSubCommand := flag.NewFlagSet("agenda", flag.ExitOnError)
+	agendaDays := agendaSubCommand.Int("days", 7, "Number of days to show, starting today")
+	agendaSubCommand.Parse(c.args)
+
+	if *agendaDays < 1 {
+		exitOnError(fmt.Errorf("days must be at least 1"))
+	}
+
+	node, err := filters.Parse(filters.JoinArgs(agendaSubCommand.Args()))
+	exitOnError(err)
+
+	tasks, err := c.store.Query(models.Query{Filter: filters.Filter(node), Where: filters.Where(node)})
+	exitOnError(err)
+
+	agenda := &renderers.Agenda{
+		Days:       *agendaDays,
+		DateFormat: c.config.DateFormat,
+		Now:        time.Now(),
+		Painter:    c.painter,
+	}
+
+	if c.dates != renderers.ABSOLUTE {
+		agenda.Humanizer = &c.humanizer
+	}
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteRecords(os.Stdout, c.output, "", "tasks", renderers.TaskRecords(agenda.Tasks(tasks)))
+		exitOnError(err)
+		return
+	}
+
+	err = agenda.Render(os.Stdout, tasks)
+	exitOnError(err)
+}
+
+func (c *commandLine) calendarCommand() {
+	calendarSubCommand := flag.NewFlagSet("calendar", flag.ExitOnError)
+	calendarSubCommand.Parse(c.args)
+
+	month, err := renderers.ParseMonth(calendarSubCommand.Arg(0), time.Now())
+	exitOnError(err)
+
+	node, err := filters.Parse(filters.JoinArgs(calendarSubCommand.Args()[min(1, calendarSubCommand.NArg()):]))
+	exitOnError(err)
+
+	tasks, err := c.store.Query(models.Query{Filter: filters.Filter(node), Where: filters.Where(node)})
+	exitOnError(err)
+
+	calendar := &renderers.Calendar{
+		Month:   month,
+		Now:     time.Now(),
+		Painter: c.painter,
+	}
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteRecords(os.Stdout, c.output, "", "tasks", renderers.TaskRecords(calendar.Tasks(tasks)))
+		exitOnError(err)
+		return
+	}
+
+	err = calendar.Render(os.Stdout, tasks)
+	exitOnError(err)
+}
+
+func (c *commandLine) statsCommand() {
+	statsSubCommand := flag.NewFlagSet("stats", flag.ExitOnError)
+	statsDays := statsSubCommand.Int("days", 14, "Number of days in the daily completion trend")
+	statsWeeks := statsSubCommand.Int("weeks", 8, "Number of weeks in the weekly completion trend")
+	statsSubCommand.Parse(c.args)
+
+	if *statsDays < 1 || *statsWeeks < 1 {
+		exitOnError(fmt.Errorf("days and weeks must be at least 1"))
+	}
+
+	node, err := filters.Parse(filters.JoinArgs(statsSubCommand.Args()))
+	exitOnError(err)
+
+	tasks, err := c.store.Query(models.Query{Filter: filters.Filter(node), Where: filters.Where(node)})
+	exitOnError(err)
+
+	now := time.Now()
+	stats := reports.ComputeStats(tasks, now, *statsDays, *statsWeeks)
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteDocument(os.Stdout, c.output, struct {
+			SchemaVersion int `json:"schema_version"`
+			reports.Stats
+		}{renderers.SchemaVersion, stats})
+		exitOnError(err)
+		return
+	}
+
+	err = stats.Render(os.Stdout, c.painter, now)
+	exitOnError(err)
+}
+
+func (c *commandLine) chartCommand() {
+	if len(c.args) < 1 || (c.args[0] != "burndown" && c.args[0] != "cfd") {
+		exitOnError(fmt.Errorf("usage: chart <burndown|cfd> [--project name] [--from date] [--to date] [--svg file] [query]"))
+	}
+
+	chartSubCommand := flag.NewFlagSet("chart "+c.args[0], flag.ExitOnError)
+	chartFrom := chartSubCommand.String("from", "-13d", "First day of the chart (YYYY-MM-DD, today, -Nd, -Nw)")
+	chartTo := chartSubCommand.String("to", "today", "Last day of the chart (YYYY-MM-DD, today, -Nd, -Nw)")
+	chartSvg := chartSubCommand.String("svg", "", "Also write the chart as an SVG image to this file")
+	chartProject := chartSubCommand.String("project", "", "Only chart the tasks of this project")
+	chartSubCommand.Parse(c.args[1:])
+
+	from, err := filters.ParseDate(*chartFrom)
+	exitOnError(err)
+	to, err := filters.ParseDate(*chartTo)
+	exitOnError(err)
+
+	if to.Before(from) {
+		exitOnError(fmt.Errorf("--to must not be before --from"))
+	}
+
+	node, err := filters.Parse(filters.JoinArgs(chartSubCommand.Args()))
+	exitOnError(err)
+
+	if *chartProject != "" {
+		project, err := filters.Equals("project", *chartProject)
+		exitOnError(err)
+		node = &filters.And{Nodes: []filters.Node{node, project}}
+	}
+
+	tasks, err := c.store.Query(models.Query{Filter: filters.Filter(node), Where: filters.Where(node)})
+	exitOnError(err)
+
+	chart := reports.Burndown(tasks, from, to)
+	if c.args[0] == "cfd" {
+		chart = reports.CumulativeFlow(tasks, from, to)
+	}
+
+	if *chartSvg != "" {
+		file, err := os.Create(*chartSvg)
+		exitOnError(err)
+		defer file.Close()
+
+		err = chart.RenderSVG(file)
+		exitOnError(err)
+	}
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteDocument(os.Stdout, c.output, struct {
+			SchemaVersion int `json:"schema_version"`
+			reports.Chart
+		}{renderers.SchemaVersion, chart})
+		exitOnError(err)
+		return
+	}
+
+	err = chart.RenderASCII(os.Stdout, c.painter, renderers.TerminalWidth(os.Stdout))
+	exitOnError(err)
+}
+
+func (c *commandLine) standupCommand() {
+	standupSubCommand := flag.NewFlagSet("standup", flag.ExitOnError)
+	standupMarkdown := standupSubCommand.Bool("markdown", false, "Format the report as Markdown")
+	standupSubCommand.Parse(c.args)
+
+	node, err := filters.Parse(filters.JoinArgs(standupSubCommand.Args()))
+	exitOnError(err)
+
+	all, err := c.store.Query(models.Query{})
+	exitOnError(err)
+
+	tasks := models.Query{Filter: filters.Filter(node)}.Apply(all)
+	standup := reports.ComputeStandup(tasks, all, time.Now())
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteDocument(os.Stdout, c.output, struct {
+			SchemaVersion int `json:"schema_version"`
+			reports.Standup
+		}{renderers.SchemaVersion, standup})
+		exitOnError(err)
+		return
+	}
+
+	err = standup.Render(os.Stdout, c.painter, *standupMarkdown)
+	exitOnError(err)
+}
+
+func (c *commandLine) viewCommand() {
+	if len(c.args) < 1 {
+		fmt.Println("Please provide a view subcommand: save, list, delete")
 		os.Exit(1)
 	}
 
-	listByStatus := os.Args[2]
+	switch c.args[0] {
+	case "save":
+		viewSaveSubCommand := flag.NewFlagSet("view save", flag.ExitOnError)
+		viewSort := viewSaveSubCommand.String("sort", "", "Comma separated sort fields of the view")
+		viewSaveSubCommand.Parse(c.args[1:])
 
-	if *listTodo {
-		listByStatus = "todo"
-	} else if *listInProgress {
-		listByStatus = "in-progress"
-	} else if *listDone {
-		listByStatus = "done"
+		if viewSaveSubCommand.NArg() < 2 {
+			fmt.Println("Usage: view save [-sort fields] <name> <query>")
+			os.Exit(1)
+		}
+
+		name := strings.TrimPrefix(viewSaveSubCommand.Arg(0), "@")
+		query := filters.JoinArgs(viewSaveSubCommand.Args()[1:])
+
+		_, err := filters.Parse(query)
+		exitOnError(err)
+
+		_, err = filters.ParseSort(*viewSort)
+		exitOnError(err)
+
+		err = c.config.SaveView(name, configs.View{Query: query, Sort: *viewSort})
+		exitOnError(err)
+		fmt.Printf("View saved successfully, use it with: list @%s\n", name)
+	case "list":
+		if c.output != renderers.TEXT {
+			records := []any{}
+			for _, name := range c.config.ViewNames() {
+				view, _ := c.config.View(name)
+				_, saved := c.config.Views[name]
+				records = append(records, struct {
+					Name    string `json:"name"`
+					Query   string `json:"query"`
+					Sort    string `json:"sort"`
+					Builtin bool   `json:"builtin"`
+				}{name, view.Query, view.Sort, !saved})
+			}
+
+			err := renderers.WriteRecords(os.Stdout, c.output, "", "views", records)
+			exitOnError(err)
+			return
+		}
+
+		for _, name := range c.config.ViewNames() {
+			view, _ := c.config.View(name)
+			line := fmt.Sprintf("@%s: %s", name, view.Query)
+			if view.Sort != "" {
+				line += ", sort: " + view.Sort
+			}
+			if _, ok := c.config.Views[name]; !ok {
+				line += " (built-in)"
+			}
+			fmt.Println(line)
+		}
+	case "delete":
+		if len(c.args) < 2 {
+			fmt.Println("Usage: view delete <name>")
+			os.Exit(1)
+		}
+
+		err := c.config.DeleteView(strings.TrimPrefix(c.args[1], "@"))
+		exitOnError(err)
+	default:
+		fmt.Println("Invalid view subcommand. Expected: save, list, delete")
+		os.Exit(1)
+	}
+}
+
+func (c *commandLine) table(columns string) *renderers.Table {
+	table := &renderers.Table{
+		Columns:    c.config.Columns,
+		DateFormat: c.config.DateFormat,
+		Width:      renderers.TerminalWidth(os.Stdout),
+		Painter:    c.painter,
+	}
+
+	if c.dates != renderers.ABSOLUTE {
+		table.Humanizer = &c.humanizer
+	}
+
+	if columns != "" {
+		parsed, err := renderers.ParseColumns(columns)
+		exitOnError(err)
+		table.Columns = parsed
+	}
+	return table
+}
+
+func (c *commandLine) printTasks(table *renderers.Table, tasks []*models.Task) {
+	if c.output != renderers.TEXT {
+		err := renderers.WriteRecords(os.Stdout, c.output, "", "tasks", renderers.TaskRecords(tasks))
+		exitOnError(err)
+		return
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println(models.NoTaskString)
+		return
+	}
+
+	err := table.Render(os.Stdout, tasks)
+	exitOnError(err)
+}
+
+// printAffected reports the tasks a mutating command changed.
+func (c *commandLine) printAffected(action string, message string, tasks ...*models.Task) {
+	if c.output == renderers.TEXT {
+		fmt.Println(message)
+		return
+	}
+
+	err := renderers.WriteRecords(os.Stdout, c.output, action, "tasks", renderers.TaskRecords(tasks))
+	exitOnError(err)
+}
+
+func (c *commandLine) findTask(id int) *models.Task {
+	tasks, err := c.store.Query(models.Query{Filter: func(task *models.Task) bool {
+		return task.Id == id
+	}})
+	exitOnError(err)
+
+	if len(tasks) == 0 {
+		exitOnError(fmt.Errorf("task with ID %d not found", id))
+	}
+	return tasks[0]
+}
+
+// checkDependencies exits unless every dependency is a stored task and none
+// of them waits, directly or not, on the task with id.
+func (c *commandLine) checkDependencies(id int, dependsOn []int) []int {
+	tasks, err := c.store.Query(models.Query{})
+	exitOnError(err)
+
+	byId := map[int]*models.Task{}
+	for _, task := range tasks {
+		byId[task.Id] = task
+	}
+
+	pending := slices.Clone(dependsOn)
+	seen := map[int]bool{}
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+
+		if next == id {
+			exitOnError(fmt.Errorf("task %d would end up depending on itself", id))
+		}
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+
+		task, ok := byId[next]
+		if !ok {
+			exitOnError(fmt.Errorf("task with ID %d not found", next))
+		}
+		pending = append(pending, task.DependsOn...)
+	}
+	return dependsOn
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func (c *commandLine) searchCommand() {
+	searchSubCommand := flag.NewFlagSet("search", flag.ExitOnError)
+	searchLimit := searchSubCommand.Int("limit", 0, "Maximum number of results")
+	searchSubCommand.Parse(c.args)
+
+	if searchSubCommand.NArg() == 0 {
+		fmt.Println("Please provide the terms to search for")
+		os.Exit(1)
+	}
+
+	results, err := c.store.Search(filters.JoinArgs(searchSubCommand.Args()))
+	exitOnError(err)
+
+	if *searchLimit > 0 && *searchLimit < len(results) {
+		results = results[:*searchLimit]
+	}
+
+	if c.output != renderers.TEXT {
+		records := []any{}
+		for _, result := range results {
+			records = append(records, renderers.SearchRecord{
+				Score:   result.Score,
+				Matches: result.Matches,
+				Task:    renderers.NewTaskRecord(result.Task),
+			})
+		}
+
+		err := renderers.WriteRecords(os.Stdout, c.output, "", "results", records)
+		exitOnError(err)
+		return
+	}
+
+	if len(results) == 0 {
+		fmt.Println(models.NoTaskString)
+		return
+	}
+
+	mark := func(word string) string {
+		return "*" + word + "*"
+	}
+	if c.painter.Enabled() {
+		mark = func(word string) string {
+			return c.painter.Paint("match", word)
+		}
+	}
+
+	for _, result := range results {
+		task := result.Task
+		fmt.Printf("ID: %d, Description: %s, Status: %s, Tags: %s, Score: %.2f\n",
+			task.Id,
+			highlight(task.Description, result.Matches, mark),
+			c.painter.Status(task.Status),
+			highlight(strings.Join(task.Tags, ", "), result.Matches, mark),
+			result.Score,
+		)
+		if task.Notes != "" {
+			fmt.Printf("    Notes: %s\n", highlight(task.Notes, result.Matches, mark))
+		}
 	}
-	c.selectList(listByStatus)
 }
 
 func (c *commandLine) Run() {
-	if len(os.Args) < 2 {
-		fmt.Println("Please provide a subcommand: add, update, delete, mark-done, mark-in-progress, list")
+	globalFlags := flag.NewFlagSet("task-cli", flag.ExitOnError)
+	color := globalFlags.String("color", c.config.Color, "Color output: auto, always, never")
+	output := globalFlags.String("output", c.config.Output, "Output format: text, json, ndjson, yaml")
+	globalFlags.StringVar(output, "o", c.config.Output, "Shorthand for -output")
+	dates := globalFlags.String("dates", c.config.Dates, "Show dates as: absolute, relative, compact")
+	storeSpec := globalFlags.String("store", c.config.Store, "Task store as backend:path, json:tasks.json by default or todotxt:todo.txt")
+	globalFlags.Parse(os.Args[1:])
+
+	if *storeSpec == "" {
+		*storeSpec = "json:tasks.json"
+	}
+	store, err := c.open(*storeSpec)
+	exitOnError(err)
+	c.store = store
+
+	location, err := c.config.Location()
+	exitOnError(err)
+	time.Local = location
+
+	c.dates, err = renderers.ParseDates(*dates)
+	exitOnError(err)
+
+	locale, err := renderers.ParseLocale(c.config.Locale)
+	exitOnError(err)
+	c.humanizer = renderers.Humanizer{Locale: locale, Short: c.dates == renderers.COMPACT}
+
+	format, err := renderers.ParseFormat(*output)
+	exitOnError(err)
+	c.output = format
+
+	painter, err := renderers.NewPainter(*color, c.config.Theme, os.Stdout)
+	if c.output != renderers.TEXT {
+		painter, err = renderers.NewPainter("never", nil, os.Stdout)
+	}
+	exitOnError(err)
+	c.painter = painter
+
+	if globalFlags.NArg() < 1 {
+		fmt.Println("Please provide a subcommand: add, update, delete, mark-done, mark-in-progress, list, show, history, search, view, board, agenda, calendar, stats, chart, standup, export, import, sync, backup, restore, migrate, doctor")
 		os.Exit(1)
 	}
 
-	switch os.Args[1] {
+	c.args = globalFlags.Args()[1:]
+
+	switch globalFlags.Arg(0) {
 	case "add":
 		c.addTaskCommand()
 	case "update":
@@ -124,9 +847,43 @@ func (c *commandLine) Run() {
 		c.markAsTaskInProgressCommand()
 	case "list":
 		c.listCommand()
+	case "show":
+		c.showCommand()
+	case "history":
+		c.historyCommand()
+	case "search":
+		c.searchCommand()
+	case "view":
+		c.viewCommand()
+	case "board":
+		c.boardCommand()
+	case "agenda":
+		c.agendaCommand()
+	case "calendar":
+		c.calendarCommand()
+	case "stats":
+		c.statsCommand()
+	case "chart":
+		c.chartCommand()
+	case "standup":
+		c.standupCommand()
+	case "export":
+		c.exportCommand()
+	case "import":
+		c.importCommand()
+	case "sync":
+		c.syncCommand()
+	case "backup":
+		c.backupCommand()
+	case "restore":
+		c.restoreCommand()
+	case "migrate":
+		c.migrateCommand()
+	case "doctor":
+		c.doctorCommand()
 
 	default:
-		fmt.Println("Invalid subcommand. Expected: add, update, delete, mark-done, mark-in-progress, list")
+		fmt.Println("Invalid subcommand. Expected: add, update, delete, mark-done, mark-in-progress, list, show, history, search, view, board, agenda, calendar, stats, chart, standup, export, import, sync, backup, restore, migrate, doctor")
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"task-tracker/models"
+	"task-tracker/renderers"
+	"task-tracker/stores"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChartCommand(t *testing.T) {
+	asserts := assert.New(t)
+
+	t.Run("✅ Should only chart the tasks of the project", func(t *testing.T) {
+		created := time.Date(2024, 8, 19, 9, 0, 0, 0, time.Local)
+		store := stores.NewJsonTaskStore(filepath.Join(t.TempDir(), "tasks.json"))
+		store.ImportTasks([]*models.Task{
+			{Description: "Fix login", Project: "Web", CreatedAt: created},
+			{Description: "Style header", Project: "web", CreatedAt: created},
+			{Description: "Write docs", Project: "docs", CreatedAt: created},
+		})
+
+		command := &commandLine{store: store, output: renderers.JSON, args: []string{"burndown", "--project", "web", "--from", "2024-08-20", "--to", "2024-08-21"}}
+		chart := struct {
+			Series []struct {
+				Name   string    `json:"name"`
+				Values []float64 `json:"values"`
+			} `json:"series"`
+		}{}
+		asserts.NoError(json.Unmarshal([]byte(captureOutput(command.chartCommand)), &chart))
+
+		asserts.Equal("remaining", chart.Series[0].Name)
+		asserts.Equal([]float64{2, 2}, chart.Series[0].Values)
+	})
+
+	t.Run("✅ Should chart a project with quotes in its name", func(t *testing.T) {
+		created := time.Date(2024, 8, 19, 9, 0, 0, 0, time.Local)
+		store := stores.NewJsonTaskStore(filepath.Join(t.TempDir(), "tasks.json"))
+		store.ImportTasks([]*models.Task{
+			{Description: "Record intro", Project: `The "Late" Show`, CreatedAt: created},
+			{Description: "Book guests", Project: "Show", CreatedAt: created},
+		})
+
+		command := &commandLine{store: store, output: renderers.JSON, args: []string{"burndown", "--project", `The "Late" Show`, "--from", "2024-08-20", "--to", "2024-08-21"}}
+		chart := struct {
+			Series []struct {
+				Values []float64 `json:"values"`
+			} `json:"series"`
+		}{}
+		asserts.NoError(json.Unmarshal([]byte(captureOutput(command.chartCommand)), &chart))
+
+		asserts.Equal([]float64{1, 1}, chart.Series[0].Values)
+	})
+}
+
+// captureOutput returns what run writes to stdout.
+func captureOutput(run func()) string {
+	stdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	run()
+
+	w.Close()
+	os.Stdout = stdout
+
+	var buffer bytes.Buffer
+	io.Copy(&buffer, r)
+	return buffer.String()
+}
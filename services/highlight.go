@@ -0,0 +1,38 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// highlight passes every word of text found in matches through mark.
+func highlight(text string, matches []string, mark func(string) string) string {
+	var builder strings.Builder
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			builder.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+
+		word := string(runes[i:j])
+		if slices.Contains(matches, strings.ToLower(word)) {
+			word = mark(word)
+		}
+		builder.WriteString(word)
+		i = j
+	}
+	return builder.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"task-tracker/converters"
+	"task-tracker/filters"
+	"task-tracker/models"
+	"task-tracker/renderers"
+	"task-tracker/stores"
+	"time"
+)
+
+func (c *commandLine) exportCommand() {
+	exportSubCommand := flag.NewFlagSet("export", flag.ExitOnError)
+	exportFormat := exportSubCommand.String("format", "", "Export format: "+strings.Join(converters.Formats, ", ")+" (detected from --file when empty)")
+	exportFile := exportSubCommand.String("file", "", "Write to this file instead of stdout")
+	exportColumns := exportSubCommand.String("columns", "", "CSV columns, every task field by default")
+	exportHeader := exportSubCommand.Bool("header", true, "Write a CSV header row")
+	exportDateFormat := exportSubCommand.String("date-format", "", "Go layout for CSV dates, RFC 3339 by default")
+	exportGroup := exportSubCommand.String("group", converters.GroupStatus, "Group Markdown checklists by: status, tag, none")
+	exportSubCommand.Parse(c.args)
+
+	format := c.transferFormat(*exportFormat, *exportFile)
+
+	node, err := filters.Parse(filters.JoinArgs(exportSubCommand.Args()))
+	exitOnError(err)
+
+	tasks, err := c.store.Query(models.Query{Filter: filters.Filter(node), Where: filters.Where(node)})
+	exitOnError(err)
+
+	var w io.Writer = os.Stdout
+	if *exportFile != "" {
+		file, err := os.Create(*exportFile)
+		exitOnError(err)
+		defer file.Close()
+		w = file
+	}
+
+	switch format {
+	case converters.CSV:
+		columns, err := converters.ParseCSVColumns(*exportColumns)
+		exitOnError(err)
+
+		options := converters.CSVOptions{Columns: columns, DateFormat: *exportDateFormat}
+		if !*exportHeader {
+			options.Header = converters.HeaderNo
+		}
+		err = converters.WriteCSV(w, tasks, options)
+		exitOnError(err)
+	case converters.MARKDOWN:
+		if *exportGroup != converters.GroupStatus && *exportGroup != converters.GroupTag && *exportGroup != converters.GroupNone {
+			exitOnError(fmt.Errorf("invalid group %q, expected: status, tag, none", *exportGroup))
+		}
+		err = converters.WriteMarkdown(w, tasks, *exportGroup)
+		exitOnError(err)
+	case converters.ICS:
+		err = converters.WriteICS(w, tasks, time.Now())
+		exitOnError(err)
+	case converters.TODOTXT:
+		err = converters.WriteTodoTxt(w, tasks)
+		exitOnError(err)
+	case converters.TASKWARRIOR:
+		err = converters.WriteTaskwarrior(w, tasks)
+		exitOnError(err)
+	}
+
+	if *exportFile != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d tasks to %s\n", len(tasks), *exportFile)
+	}
+}
+
+func (c *commandLine) importCommand() {
+	importSubCommand := flag.NewFlagSet("import", flag.ExitOnError)
+	importFormat := importSubCommand.String("format", "", "Import format: "+strings.Join(converters.Formats, ", ")+" (detected from the file name when empty)")
+	importSubCommand.StringVar(importFormat, "from", "", "Shorthand for -format")
+	importDryRun := importSubCommand.Bool("dry-run", false, "Preview the tasks without saving them")
+	importUpdate := importSubCommand.Bool("update", false, "Replace stored tasks with the same ID instead of adding new ones")
+	importColumns := importSubCommand.String("columns", "", "CSV columns of a file without header")
+	importMapping := importSubCommand.String("map", "", "Map CSV columns to task fields: \"Title=description,State=status\"")
+	importHeader := importSubCommand.String("header", converters.HeaderAuto, "Whether the CSV has a header row: auto, yes, no")
+	importDateFormat := importSubCommand.String("date-format", "", "Go layout for CSV dates, RFC 3339 and YYYY-MM-DD are always accepted")
+	importSubCommand.Parse(c.args)
+
+	if importSubCommand.NArg() != 1 {
+		exitOnError(fmt.Errorf("please provide the file to import, - for stdin"))
+	}
+	fileName := importSubCommand.Arg(0)
+	format := c.transferFormat(*importFormat, fileName)
+
+	var r io.Reader = os.Stdin
+	if fileName != "-" {
+		file, err := os.Open(fileName)
+		exitOnError(err)
+		defer file.Close()
+		r = file
+	}
+
+	var result *converters.Result
+
+	switch format {
+	case converters.CSV:
+		columns, err := converters.ParseCSVColumns(*importColumns)
+		exitOnError(err)
+		mapping, err := converters.ParseMapping(*importMapping)
+		exitOnError(err)
+
+		if *importHeader != converters.HeaderAuto && *importHeader != converters.HeaderYes && *importHeader != converters.HeaderNo {
+			exitOnError(fmt.Errorf("invalid header %q, expected: auto, yes, no", *importHeader))
+		}
+
+		result, err = converters.ReadCSV(r, converters.CSVOptions{
+			Columns:    columns,
+			Mapping:    mapping,
+			Header:     *importHeader,
+			DateFormat: *importDateFormat,
+		})
+		exitOnError(err)
+	case converters.MARKDOWN:
+		var err error
+		result, err = converters.ReadMarkdown(r)
+		exitOnError(err)
+	case converters.ICS:
+		var err error
+		result, err = converters.ReadICS(r)
+		exitOnError(err)
+	case converters.TODOTXT:
+		var err error
+		result, err = converters.ReadTodoTxt(r)
+		exitOnError(err)
+	case converters.TASKWARRIOR:
+		var err error
+		result, err = converters.ReadTaskwarrior(r)
+		exitOnError(err)
+	}
+
+	c.importResult(result, *importUpdate, *importDryRun)
+}
+
+// importResult stores the imported tasks, or with dryRun shows them with
+// the IDs they would get. Without update the tasks are numbered after the
+// stored ones, keeping the dependencies between them.
+func (c *commandLine) importResult(result *converters.Result, update bool, dryRun bool) {
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "Warning: "+warning)
+	}
+
+	stored, err := c.store.Query(models.Query{})
+	exitOnError(err)
+
+	if !update || result.LocalIds {
+		nextId := 1
+		for _, task := range stored {
+			nextId = max(nextId, task.Id+1)
+		}
+		result.Renumber(nextId)
+	}
+
+	if !dryRun {
+		imported, err := c.store.ImportTasks(result.Tasks)
+		exitOnError(err)
+
+		c.printAffected("imported", fmt.Sprintf("Imported %d tasks", len(imported)), imported...)
+		return
+	}
+
+	preview := stores.NewInMemoryTaskStore()
+	preview.Tasks = stored
+	imported, err := preview.ImportTasks(result.Tasks)
+	exitOnError(err)
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteRecords(os.Stdout, c.output, "dry-run", "tasks", renderers.TaskRecords(imported))
+		exitOnError(err)
+		return
+	}
+
+	fmt.Printf("Dry run, %d tasks would be imported:\n", len(imported))
+	c.printTasks(c.table(""), imported)
+}
+
+func (c *commandLine) transferFormat(format string, fileName string) string {
+	if format != "" {
+		parsed, err := converters.ParseFormat(format)
+		exitOnError(err)
+		return parsed
+	}
+
+	if fileName == "" || fileName == "-" {
+		return converters.CSV
+	}
+
+	detected, err := converters.DetectFormat(fileName)
+	exitOnError(err)
+	return detected
+}
+
+func (c *commandLine) syncCommand() {
+	syncSubCommand := flag.NewFlagSet("sync", flag.ExitOnError)
+	syncDryRun := syncSubCommand.Bool("dry-run", false, "Show the changes without saving them")
+	syncPrune := syncSubCommand.Bool("prune", false, "Remove the items of tasks that are not in the store")
+	syncSubCommand.Parse(c.args)
+
+	if syncSubCommand.NArg() < 1 {
+		exitOnError(fmt.Errorf("please provide the Markdown file to sync"))
+	}
+	fileName := syncSubCommand.Arg(0)
+
+	node, err := filters.Parse(filters.JoinArgs(syncSubCommand.Args()[1:]))
+	exitOnError(err)
+
+	content, err := os.ReadFile(fileName)
+	modified := time.Time{}
+	if err == nil {
+		info, err := os.Stat(fileName)
+		exitOnError(err)
+		modified = info.ModTime()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		exitOnError(err)
+	}
+
+	tasks, err := c.store.Query(models.Query{})
+	exitOnError(err)
+
+	synced, changed, report := converters.SyncMarkdown(string(content), modified, tasks, filters.Filter(node), *syncPrune, time.Now())
+
+	if report.Missing > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d items refer to tasks that are not in the store and were kept, use --prune to remove them\n", report.Missing)
+	}
+
+	if !*syncDryRun && len(changed) > 0 {
+		_, err = c.store.ImportTasks(changed)
+		exitOnError(err)
+	}
+
+	if !*syncDryRun && synced != string(content) {
+		err = os.WriteFile(fileName, []byte(synced), 0644)
+		exitOnError(err)
+	}
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteDocument(os.Stdout, c.output, struct {
+			SchemaVersion int    `json:"schema_version"`
+			Action        string `json:"action"`
+			converters.SyncReport
+		}{renderers.SchemaVersion, "sync", report})
+		exitOnError(err)
+		return
+	}
+
+	if *syncDryRun {
+		fmt.Print(synced)
+	}
+	fmt.Printf("Synced %s: %d tasks added, %d tasks updated, %d lines updated, %d tasks appended, %d lines removed, %d missing tasks\n",
+		fileName, report.Added, report.UpdatedTasks, report.UpdatedLines, report.Appended, report.Removed, report.Missing)
+}
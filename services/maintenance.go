@@ -0,0 +1,105 @@
+package services
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"task-tracker/renderers"
+	"task-tracker/stores"
+)
+
+func (c *commandLine) migrateCommand() {
+	migrateSubCommand := flag.NewFlagSet("migrate", flag.ExitOnError)
+	migrateDryRun := migrateSubCommand.Bool("dry-run", false, "List the pending migrations without applying them")
+	migrateSubCommand.Parse(c.args)
+
+	store, ok := c.store.(*stores.JsonTaskStore)
+	if !ok {
+		exitOnError(fmt.Errorf("only the json store has migrations"))
+	}
+
+	report, err := store.Migrate(*migrateDryRun)
+	exitOnError(err)
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteDocument(os.Stdout, c.output, struct {
+			SchemaVersion int `json:"schema_version"`
+			*stores.MigrationReport
+		}{renderers.SchemaVersion, report})
+		exitOnError(err)
+		return
+	}
+
+	if len(report.Pending) == 0 {
+		fmt.Printf("%s is up to date (version %d)\n", report.File, report.Version)
+		return
+	}
+
+	verb := "Would apply"
+	if report.Applied {
+		verb = "Applied"
+	}
+	fmt.Printf("%s %d migrations to %s (version %d → %d):\n", verb, len(report.Pending), report.File, report.Version, report.Current)
+	for _, migration := range report.Pending {
+		fmt.Println("  " + migration)
+	}
+}
+
+func (c *commandLine) doctorCommand() {
+	doctorSubCommand := flag.NewFlagSet("doctor", flag.ExitOnError)
+	doctorFix := doctorSubCommand.Bool("fix", false, "Repair the fixable problems, after a backup")
+	doctorSubCommand.Parse(c.args)
+
+	store, ok := c.store.(*stores.JsonTaskStore)
+	if !ok {
+		exitOnError(fmt.Errorf("doctor only checks the json store"))
+	}
+
+	report, err := store.Doctor(*doctorFix)
+	exitOnError(err)
+
+	if c.output != renderers.TEXT {
+		err = renderers.WriteDocument(os.Stdout, c.output, struct {
+			SchemaVersion int `json:"schema_version"`
+			*stores.DoctorReport
+		}{renderers.SchemaVersion, report})
+		exitOnError(err)
+	} else {
+		printDoctorReport(report, *doctorFix)
+	}
+
+	if len(report.Findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printDoctorReport(report *stores.DoctorReport, fix bool) {
+	if report.Fixed > 0 {
+		fmt.Printf("Fixed %d problems in %s\n", report.Fixed, report.File)
+	}
+
+	if len(report.Findings) == 0 {
+		if report.Fixed == 0 {
+			fmt.Printf("%s has no problems\n", report.File)
+		}
+		return
+	}
+
+	fixable := 0
+	for _, finding := range report.Findings {
+		task := ""
+		if finding.TaskId != 0 {
+			task = fmt.Sprintf("task %d: ", finding.TaskId)
+		}
+		fmt.Printf("%s:%d:%d: %s%s [%s]\n", report.File, finding.Line, finding.Column, task, finding.Message, finding.Code)
+		if finding.Fixable {
+			fixable++
+		}
+	}
+
+	fmt.Printf("%d problems", len(report.Findings))
+	if fixable > 0 && !fix {
+		fmt.Printf(", %d fixable with --fix", fixable)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"task-tracker/filters"
+	"task-tracker/models"
+	"task-tracker/renderers"
+)
+
+const taskReferenceUsage = "ID, description prefix or fuzzy description of the task"
+
+func taskReference(flagValue string, args []string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return strings.Join(args, " ")
+}
+
+// resolveTask turns a reference into a task ID, asking which task was meant
+// when the reference is ambiguous and stdin is a terminal.
+func (c *commandLine) resolveTask(reference string) int {
+	if reference == "" {
+		exitOnError(fmt.Errorf("please provide the ID or description of the task"))
+	}
+
+	tasks, err := c.store.Query(models.Query{})
+	exitOnError(err)
+
+	candidates := filters.MatchReference(tasks, reference)
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0].Id
+	case 0:
+		exitOnError(fmt.Errorf("no task matches %q", reference))
+	}
+
+	ambiguous := fmt.Sprintf("%q matches %d tasks, use the ID of one of them:", reference, len(candidates))
+	for _, task := range candidates {
+		ambiguous += fmt.Sprintf("\n  %d: %s", task.Id, task.Description)
+	}
+
+	if !renderers.IsTerminal(os.Stdin) {
+		exitOnError(fmt.Errorf("%s", ambiguous))
+	}
+
+	fmt.Printf("%q matches %d tasks:\n", reference, len(candidates))
+	for i, task := range candidates {
+		fmt.Printf("  [%d] ID: %d, Description: %s, Status: %s\n", i+1, task.Id, task.Description, task.Status)
+	}
+	fmt.Print("Which one? ")
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && strings.TrimSpace(answer) == "" {
+		fmt.Println()
+		exitOnError(fmt.Errorf("%s", ambiguous))
+	}
+
+	choice, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || choice < 1 || choice > len(candidates) {
+		exitOnError(fmt.Errorf("no task selected"))
+	}
+	return candidates[choice-1].Id
+}
@@ -0,0 +1,230 @@
+package stores
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	backupVersion  = 1
+	backupManifest = "manifest.json"
+	backupLayout   = "20060102-150405.000000"
+)
+
+type (
+	// FileStore is a store kept in files, which can be backed up.
+	FileStore interface {
+		Files() []string
+	}
+
+	BackupManifest struct {
+		Version   int          `json:"version"`
+		CreatedAt time.Time    `json:"created_at"`
+		Files     []BackupFile `json:"files"`
+	}
+
+	BackupFile struct {
+		Name   string `json:"name"`
+		Path   string `json:"path"`
+		Size   int64  `json:"size"`
+		Sha256 string `json:"sha256"`
+	}
+)
+
+// WriteBackup archives files as a gzipped tar with a manifest of their
+// sizes and checksums. Missing files are left out.
+func WriteBackup(w io.Writer, files []string, now time.Time) (*BackupManifest, error) {
+	manifest := &BackupManifest{Version: backupVersion, CreatedAt: now.UTC()}
+	contents := map[string][]byte{}
+
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := filepath.Base(path)
+		contents[name] = content
+		manifest.Files = append(manifest.Files, BackupFile{Name: name, Path: path, Size: int64(len(content)), Sha256: checksum(content)})
+	}
+
+	encoded, err := json.MarshalIndent(manifest, "", " ")
+	if err != nil {
+		return nil, err
+	}
+
+	compressed := gzip.NewWriter(w)
+	archive := tar.NewWriter(compressed)
+	add := func(name string, content []byte) error {
+		err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: now})
+		if err != nil {
+			return err
+		}
+		_, err = archive.Write(content)
+		return err
+	}
+
+	if err := add(backupManifest, encoded); err != nil {
+		return nil, err
+	}
+	for _, file := range manifest.Files {
+		if err := add(file.Name, contents[file.Name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, compressed.Close()
+}
+
+// ReadBackup reads an archive written by WriteBackup, checking every file
+// against the manifest.
+func ReadBackup(r io.Reader) (*BackupManifest, map[string][]byte, error) {
+	compressed, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid backup: %w", err)
+	}
+
+	contents := map[string][]byte{}
+	archive := tar.NewReader(compressed)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid backup: %w", err)
+		}
+
+		content, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid backup: %w", err)
+		}
+		contents[header.Name] = content
+	}
+
+	manifest := &BackupManifest{}
+	encoded, ok := contents[backupManifest]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid backup: %s is missing", backupManifest)
+	}
+	if err := json.Unmarshal(encoded, manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid backup manifest: %w", err)
+	}
+	if manifest.Version > backupVersion {
+		return nil, nil, fmt.Errorf("backup version %d is newer than the supported %d", manifest.Version, backupVersion)
+	}
+	delete(contents, backupManifest)
+
+	for _, file := range manifest.Files {
+		content, ok := contents[file.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid backup: %s is missing", file.Name)
+		}
+
+		if int64(len(content)) != file.Size || checksum(content) != file.Sha256 {
+			return nil, nil, fmt.Errorf("invalid backup: %s does not match its checksum", file.Name)
+		}
+		if strings.HasSuffix(file.Name, ".json") && !json.Valid(content) {
+			return nil, nil, fmt.Errorf("invalid backup: %s is not valid JSON", file.Name)
+		}
+	}
+	return manifest, contents, nil
+}
+
+// RestoreBackup validates an archive and writes its files over files,
+// matched by name. It refuses archives of a store with other files.
+func RestoreBackup(r io.Reader, files []string) (*BackupManifest, error) {
+	manifest, contents, err := ReadBackup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := map[string]string{}
+	for _, path := range files {
+		targets[filepath.Base(path)] = path
+	}
+	for _, file := range manifest.Files {
+		if _, ok := targets[file.Name]; !ok {
+			return nil, fmt.Errorf("backup file %s does not belong to this store", file.Name)
+		}
+	}
+
+	for _, file := range manifest.Files {
+		err := writeFileAtomic(targets[file.Name], contents[file.Name])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// RotateBackup writes a backup of files into dir and keeps only the newest
+// keep backups of the same store there.
+func RotateBackup(files []string, dir string, keep int, now time.Time) (string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := strings.TrimSuffix(filepath.Base(files[0]), filepath.Ext(files[0])) + "-"
+	path := filepath.Join(dir, prefix+now.Format(backupLayout)+".tar.gz")
+
+	var buffer bytes.Buffer
+	if _, err := WriteBackup(&buffer, files, now); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		return "", err
+	}
+
+	entries, err := filepath.Glob(filepath.Join(dir, prefix+"*.tar.gz"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(entries)
+	for len(entries) > keep {
+		if err := os.Remove(entries[0]); err != nil {
+			return "", err
+		}
+		entries = entries[1:]
+	}
+	return path, nil
+}
+
+// BackupName is the default name of a backup of files taken at now.
+func BackupName(files []string, now time.Time) string {
+	return strings.TrimSuffix(filepath.Base(files[0]), filepath.Ext(files[0])) + "-backup-" + now.Format(backupLayout) + ".tar.gz"
+}
+
+func writeFileAtomic(path string, content []byte) error {
+	temporary := path + ".tmp"
+	err := os.WriteFile(temporary, content, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporary, path)
+}
+
+// checksum is the hex encoded sha256 of content.
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
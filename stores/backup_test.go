@@ -0,0 +1,75 @@
+package stores
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackup(t *testing.T) {
+	asserts := assert.New(t)
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "tasks.json")
+	now := time.Date(2024, 8, 28, 12, 0, 0, 0, time.UTC)
+
+	t.Run("✅ Should back up and restore the task file", func(t *testing.T) {
+		os.WriteFile(fileName, []byte(`[{"id":1,"description":"Keep me"}]`), 0644)
+
+		var archive bytes.Buffer
+		manifest, err := WriteBackup(&archive, []string{fileName}, now)
+		asserts.NoError(err)
+		asserts.Equal("tasks.json", manifest.Files[0].Name)
+		asserts.Len(manifest.Files[0].Sha256, 64)
+
+		os.WriteFile(fileName, []byte(`[]`), 0644)
+		_, err = RestoreBackup(&archive, []string{fileName})
+		asserts.NoError(err)
+
+		content, _ := os.ReadFile(fileName)
+		asserts.Equal(`[{"id":1,"description":"Keep me"}]`, string(content))
+	})
+
+	t.Run("❌ Should refuse corrupted archives and other stores", func(t *testing.T) {
+		_, _, err := ReadBackup(bytes.NewReader([]byte("not an archive")))
+		asserts.ErrorContains(err, "invalid backup")
+
+		var archive bytes.Buffer
+		WriteBackup(&archive, []string{fileName}, now)
+		corrupted := bytes.Clone(archive.Bytes())
+		corrupted[len(corrupted)/2] ^= 0xff
+		_, _, err = ReadBackup(bytes.NewReader(corrupted))
+		asserts.Error(err)
+
+		_, err = RestoreBackup(&archive, []string{filepath.Join(dir, "todo.txt")})
+		asserts.EqualError(err, "backup file tasks.json does not belong to this store")
+	})
+
+	t.Run("✅ Should rotate backups before removing tasks", func(t *testing.T) {
+		os.Remove(fileName)
+		store := NewJsonTaskStore(fileName)
+		store.BackupDir = filepath.Join(dir, ".backups")
+		store.KeepBackups = 2
+
+		for i := 1; i <= 4; i++ {
+			store.AddTask(&models.Task{Description: "Task"})
+		}
+		for i := 1; i <= 3; i++ {
+			_, err := store.RemoveTask(i)
+			asserts.NoError(err)
+		}
+
+		backups, _ := filepath.Glob(filepath.Join(dir, ".backups", "tasks-*.tar.gz"))
+		asserts.Len(backups, 2)
+
+		archive, _ := os.Open(backups[1])
+		defer archive.Close()
+		_, contents, err := ReadBackup(archive)
+		asserts.NoError(err)
+		asserts.Contains(string(contents["tasks.json"]), `"id": 3`)
+	})
+}
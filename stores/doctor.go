@@ -0,0 +1,386 @@
+package stores
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"task-tracker/models"
+	"time"
+)
+
+// clockSkew is how far in the future a timestamp may be before doctor
+// reports it.
+const clockSkew = time.Minute
+
+type (
+	// Finding is one problem of the task file at a 1-based line and column.
+	Finding struct {
+		Line    int    `json:"line"`
+		Column  int    `json:"column"`
+		TaskId  int    `json:"task_id,omitempty"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Fixable bool   `json:"fixable"`
+	}
+
+	DoctorReport struct {
+		File     string    `json:"file"`
+		Findings []Finding `json:"findings"`
+		Fixed    int       `json:"fixed"`
+	}
+
+	// objectPosition holds the offsets of a task object and of its fields.
+	objectPosition struct {
+		offset int64
+		fields map[string]int64
+	}
+)
+
+// Diagnose validates a task file of any known version: duplicate and
+// invalid IDs, unknown statuses and priorities, missing or future
+// timestamps, history entries pointing at unknown statuses and dependencies
+// on missing tasks.
+func Diagnose(content []byte, now time.Time) []Finding {
+	findings := []Finding{}
+	at := func(offset int64, id int, code string, fixable bool, format string, args ...any) {
+		line, column := lineColumn(content, offset)
+		findings = append(findings, Finding{line, column, id, code, fmt.Sprintf(format, args...), fixable})
+	}
+
+	if err := json.Unmarshal(content, new(any)); err != nil {
+		var syntax *json.SyntaxError
+		offset := int64(0)
+		if errors.As(err, &syntax) {
+			offset = syntax.Offset - 1
+		}
+		at(offset, 0, "syntax", false, "invalid JSON: %s", err)
+		return findings
+	}
+
+	file, _, err := migrate(content, now)
+	if err != nil {
+		at(0, 0, "format", false, "%s", err)
+		return findings
+	}
+
+	positions, err := taskPositions(content)
+	if err != nil || len(positions) != len(file.Tasks) {
+		at(0, 0, "format", false, "tasks are not a list of objects")
+		return findings
+	}
+
+	seen := map[int]int64{}
+	for i, task := range file.Tasks {
+		position := positions[i]
+		field := func(name string) int64 {
+			if offset, ok := position.fields[name]; ok {
+				return offset
+			}
+			return position.offset
+		}
+
+		switch first, duplicate := seen[task.Id]; {
+		case task.Id <= 0:
+			at(field("id"), task.Id, "invalid-id", true, "invalid id %d", task.Id)
+		case duplicate:
+			line, column := lineColumn(content, first)
+			at(field("id"), task.Id, "duplicate-id", true, "duplicate id %d, first used at %d:%d", task.Id, line, column)
+		default:
+			seen[task.Id] = field("id")
+		}
+
+		if task.Description == "" {
+			at(field("description"), task.Id, "empty-description", false, "empty description")
+		}
+		if task.Status.Rank() == 0 {
+			at(field("status"), task.Id, "invalid-status", true, "unknown status %q", task.Status)
+		}
+		if task.Priority != "" && task.Priority.Rank() == 0 {
+			at(field("priority"), task.Id, "invalid-priority", true, "unknown priority %q", task.Priority)
+		}
+
+		switch {
+		case task.CreatedAt.IsZero():
+			at(field("created_at"), task.Id, "missing-created-at", true, "missing created_at")
+		case task.CreatedAt.After(now.Add(clockSkew)):
+			at(field("created_at"), task.Id, "future-timestamp", true, "created_at %s is in the future", task.CreatedAt.Format(time.RFC3339))
+		}
+
+		if task.UpdatedAt != nil {
+			switch {
+			case task.UpdatedAt.After(now.Add(clockSkew)):
+				at(field("updated_at"), task.Id, "future-timestamp", true, "updated_at %s is in the future", task.UpdatedAt.Format(time.RFC3339))
+			case !task.CreatedAt.IsZero() && task.UpdatedAt.Before(task.CreatedAt):
+				at(field("updated_at"), task.Id, "updated-before-created", true, "updated_at is before created_at")
+			}
+		}
+
+		for j, change := range task.History {
+			switch {
+			case change.Status.Rank() == 0:
+				at(field("history"), task.Id, "invalid-history", true, "history entry %d has unknown status %q", j+1, change.Status)
+			case change.At.After(now.Add(clockSkew)):
+				at(field("history"), task.Id, "future-timestamp", true, "history entry %d at %s is in the future", j+1, change.At.Format(time.RFC3339))
+			case j > 0 && change.At.Before(task.History[j-1].At):
+				at(field("history"), task.Id, "unordered-history", true, "history entry %d is older than the one before", j+1)
+			}
+		}
+	}
+
+	for i, task := range file.Tasks {
+		offset := positions[i].offset
+		if field, ok := positions[i].fields["depends_on"]; ok {
+			offset = field
+		}
+
+		for _, id := range task.DependsOn {
+			switch _, ok := seen[id]; {
+			case id == task.Id:
+				at(offset, task.Id, "broken-dependency", true, "depends on itself")
+			case !ok:
+				at(offset, task.Id, "broken-dependency", true, "depends on missing task %d", id)
+			}
+		}
+	}
+	return findings
+}
+
+// Repair fixes what Diagnose reports as fixable: IDs are renumbered,
+// statuses and priorities parsed leniently or reset, missing and future
+// timestamps set from the other ones, history cleaned up and dependencies on
+// missing tasks dropped.
+func Repair(tasks []*models.Task, now time.Time) {
+	nextId := 1
+	for _, task := range tasks {
+		nextId = max(nextId, task.Id+1)
+	}
+
+	seen := map[int]bool{}
+	for _, task := range tasks {
+		if task.Id <= 0 || seen[task.Id] {
+			task.Id = nextId
+			nextId++
+		}
+		seen[task.Id] = true
+
+		if task.Status.Rank() == 0 {
+			status, err := models.ParseStatus(task.Status.String())
+			if err != nil {
+				status = models.TODO
+			}
+			task.Status = status
+		}
+		if task.Priority != "" && task.Priority.Rank() == 0 {
+			task.Priority, _ = models.ParsePriority(task.Priority.String())
+		}
+
+		history := []models.StatusChange{}
+		for _, change := range task.History {
+			if status, err := models.ParseStatus(change.Status.String()); err == nil {
+				change.Status = status
+				change.At = earliest(change.At, now)
+				history = append(history, change)
+			}
+		}
+		sort.SliceStable(history, func(i, j int) bool { return history[i].At.Before(history[j].At) })
+		task.History = slices.Clip(history)
+		if len(task.History) == 0 {
+			task.History = nil
+		}
+
+		if task.CreatedAt.IsZero() {
+			task.CreatedAt = now
+			if task.UpdatedAt != nil {
+				task.CreatedAt = *task.UpdatedAt
+			}
+			if len(task.History) > 0 {
+				task.CreatedAt = earliest(task.CreatedAt, task.History[0].At)
+			}
+		}
+		task.CreatedAt = earliest(task.CreatedAt, now)
+
+		if task.UpdatedAt != nil {
+			updatedAt := earliest(*task.UpdatedAt, now)
+			if updatedAt.Before(task.CreatedAt) {
+				updatedAt = task.CreatedAt
+			}
+			task.UpdatedAt = &updatedAt
+		}
+	}
+
+	for _, task := range tasks {
+		task.DependsOn = slices.DeleteFunc(task.DependsOn, func(id int) bool {
+			return id == task.Id || !seen[id]
+		})
+		if len(task.DependsOn) == 0 {
+			task.DependsOn = nil
+		}
+	}
+}
+
+// Doctor validates the task file, and with fix repairs it after a backup.
+func (j *JsonTaskStore) Doctor(fix bool) (*DoctorReport, error) {
+	content, err := os.ReadFile(j.JsonFileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report := &DoctorReport{File: j.JsonFileName, Findings: Diagnose(content, now)}
+
+	fixable := 0
+	for _, finding := range report.Findings {
+		if finding.Fixable {
+			fixable++
+		}
+	}
+
+	if !fix || fixable == 0 {
+		return report, nil
+	}
+
+	err = j.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.backup()
+
+	if err != nil {
+		return nil, err
+	}
+
+	Repair(j.Tasks, now)
+
+	err = j.saveToFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err = os.ReadFile(j.JsonFileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := Diagnose(content, now)
+	report.Fixed = len(report.Findings) - len(remaining)
+	report.Findings = remaining
+	return report, nil
+}
+
+// taskPositions walks the task file tokens to find where each task and
+// each of its fields start.
+func taskPositions(content []byte) ([]objectPosition, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token == json.Delim('{') {
+		for {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			if key == json.Delim('}') {
+				return nil, fmt.Errorf("missing tasks")
+			}
+			if key == "tasks" {
+				break
+			}
+			if err := skipValue(decoder); err != nil {
+				return nil, err
+			}
+		}
+
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if token != json.Delim('[') {
+		return nil, fmt.Errorf("tasks are not a list")
+	}
+
+	positions := []objectPosition{}
+	for decoder.More() {
+		position := objectPosition{offset: valueStart(content, decoder.InputOffset()), fields: map[string]int64{}}
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if token != json.Delim('{') {
+			return nil, fmt.Errorf("task is not an object")
+		}
+
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			position.fields[fmt.Sprint(key)] = valueStart(content, decoder.InputOffset())
+			if err := skipValue(decoder); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// valueStart skips the separators between the decoder offset and the
+// next value.
+func valueStart(content []byte, offset int64) int64 {
+	for offset < int64(len(content)) && bytes.IndexByte([]byte(" \t\r\n,:"), content[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func lineColumn(content []byte, offset int64) (int, int) {
+	offset = min(max(offset, 0), int64(len(content)))
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+func earliest(date time.Time, other time.Time) time.Time {
+	if other.Before(date) {
+		return other
+	}
+	return date
+}
@@ -0,0 +1,83 @@
+package stores
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctor(t *testing.T) {
+	asserts := assert.New(t)
+	now := time.Date(2024, 8, 28, 12, 0, 0, 0, time.UTC)
+	content := []byte(`{
+  "version": 2,
+  "tasks": [
+    {"id": 1, "description": "First", "status": "To do", "created_at": "2024-08-20T09:00:00Z", "updated_at": null, "depends_on": [7]},
+    {"id": 1, "description": "Second", "status": "todo", "created_at": "2024-08-20T09:00:00Z", "updated_at": null},
+    {"id": -2, "description": "", "status": "Done", "updated_at": "2030-01-01T00:00:00Z",
+     "history": [{"status": "Finished", "at": "2024-08-21T10:00:00Z"}]}
+  ]
+}`)
+
+	t.Run("✅ Should report problems with their position", func(t *testing.T) {
+		findings := Diagnose(content, now)
+
+		codes := []string{}
+		for _, finding := range findings {
+			codes = append(codes, finding.Code)
+		}
+		asserts.Equal([]string{"duplicate-id", "invalid-status", "invalid-id", "empty-description", "missing-created-at", "future-timestamp", "invalid-history", "broken-dependency"}, codes)
+		asserts.Equal(Finding{5, 12, 1, "duplicate-id", "duplicate id 1, first used at 4:12", true}, findings[0])
+		asserts.Equal(5, findings[1].Line)
+		asserts.Equal(50, findings[1].Column)
+		asserts.Equal(6, findings[4].Line)
+		asserts.Equal(5, findings[4].Column)
+		asserts.False(findings[3].Fixable)
+		asserts.Equal(Finding{4, 130, 1, "broken-dependency", "depends on missing task 7", true}, findings[7])
+	})
+
+	t.Run("❌ Should report invalid JSON", func(t *testing.T) {
+		findings := Diagnose([]byte("[\n  {\"id\": 1,}\n]"), now)
+
+		asserts.Len(findings, 1)
+		asserts.Equal("syntax", findings[0].Code)
+		asserts.Equal(2, findings[0].Line)
+		asserts.Equal(12, findings[0].Column)
+	})
+
+	t.Run("✅ Should repair the fixable problems after a backup", func(t *testing.T) {
+		dir := t.TempDir()
+		fileName := filepath.Join(dir, "tasks.json")
+		os.WriteFile(fileName, content, 0644)
+		store := NewJsonTaskStore(fileName)
+		store.BackupDir = filepath.Join(dir, ".backups")
+		store.KeepBackups = KeepBackups
+
+		report, err := store.Doctor(false)
+		asserts.NoError(err)
+		asserts.Len(report.Findings, 8)
+		asserts.Zero(report.Fixed)
+
+		report, err = store.Doctor(true)
+		asserts.NoError(err)
+		asserts.Equal(7, report.Fixed)
+		asserts.Len(report.Findings, 1)
+		asserts.Equal("empty-description", report.Findings[0].Code)
+
+		backups, _ := os.ReadDir(store.BackupDir)
+		asserts.Len(backups, 1)
+
+		ids := []int{}
+		for _, task := range store.Tasks {
+			ids = append(ids, task.Id)
+		}
+		asserts.Equal([]int{1, 2, 3}, ids)
+		asserts.Equal("To do", store.Tasks[1].Status.String())
+		asserts.Nil(store.Tasks[0].DependsOn)
+		asserts.Nil(store.Tasks[2].History)
+		asserts.False(store.Tasks[2].CreatedAt.IsZero())
+	})
+}
@@ -0,0 +1,481 @@
+package stores
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"task-tracker/models"
+	"time"
+)
+
+// SnapshotEvery is how many events an event store appends between
+// snapshots of its state.
+const SnapshotEvery = 100
+
+const (
+	TaskAdded          EventType = EventType("TaskAdded")
+	DescriptionUpdated EventType = EventType("DescriptionUpdated")
+	StatusChanged      EventType = EventType("StatusChanged")
+	TaskRemoved        EventType = EventType("TaskRemoved")
+)
+
+type (
+	EventType string
+
+	// Event is one change of an event store. TaskAdded carries the whole
+	// task and replaces a stored task with the same ID, as imports do.
+	Event struct {
+		Sequence    int           `json:"seq"`
+		Type        EventType     `json:"type"`
+		At          time.Time     `json:"at"`
+		TaskId      int           `json:"task_id"`
+		Task        *models.Task  `json:"task,omitempty"`
+		Description string        `json:"description,omitempty"`
+		Status      models.Status `json:"status,omitempty"`
+	}
+
+	// snapshot is the state after the events in the first Offset bytes of
+	// the log, which must end with Tail for the snapshot to apply.
+	snapshot struct {
+		Sequence int            `json:"seq"`
+		Offset   int            `json:"offset"`
+		Tail     string         `json:"tail"`
+		At       time.Time      `json:"at"`
+		Tasks    []*models.Task `json:"tasks"`
+	}
+
+	// EventTaskStore keeps an append-only log of events as JSON lines and
+	// rebuilds the tasks by replaying it, from the last snapshot kept next
+	// to the log when it still matches. A last line cut short by a crash is
+	// ignored and truncated before the next append.
+	EventTaskStore struct {
+		Tasks         []*models.Task
+		FileName      string
+		SnapshotEvery int
+		sequence      int
+		snapshotAt    int
+		// end is where the replayed events stop, size the length of the log
+		// when it was read and unterminated whether the last event misses
+		// its newline.
+		end          int
+		size         int
+		unterminated bool
+		index        *searchIndex
+	}
+)
+
+func NewEventTaskStore(fileName string) *EventTaskStore {
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		err := os.WriteFile(fileName, nil, 0644)
+
+		if err != nil {
+			panic("Error creating file: " + err.Error())
+		}
+	}
+
+	return &EventTaskStore{
+		Tasks:         []*models.Task{},
+		FileName:      fileName,
+		SnapshotEvery: SnapshotEvery,
+	}
+}
+
+// SnapshotFileName is where the snapshot of the log in fileName is kept.
+func SnapshotFileName(fileName string) string {
+	return fileName + ".snapshot"
+}
+
+func (s *EventTaskStore) loadFromFile() error {
+	content, err := os.ReadFile(s.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	tasks, sequence, snapshotAt, end, err := s.replay(content, time.Time{})
+
+	if err != nil {
+		return err
+	}
+
+	s.index = nil
+	s.Tasks = tasks
+	s.sequence = sequence
+	s.snapshotAt = snapshotAt
+	s.end = end
+	s.size = len(content)
+	s.unterminated = end > 0 && content[end-1] != '\n'
+	return nil
+}
+
+// AsOf returns the tasks as they were at the given time, replaying only the
+// events that happened before it.
+func (s *EventTaskStore) AsOf(at time.Time) ([]*models.Task, error) {
+	content, err := os.ReadFile(s.FileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, _, _, _, err := s.replay(content, at)
+	return tasks, err
+}
+
+// replay rebuilds the tasks from the log content, stopping at the first
+// event not before until unless it is zero. It returns the last sequence
+// replayed, the one of the snapshot it started from and the offset where
+// the events end. A last line without newline that does not decode is torn
+// and left out.
+func (s *EventTaskStore) replay(content []byte, until time.Time) ([]*models.Task, int, int, int, error) {
+	tasks := []*models.Task{}
+	sequence, offset, line := 0, 0, 1
+
+	if saved, ok := s.readSnapshot(content); ok && (until.IsZero() || saved.At.Before(until)) {
+		tasks = saved.Tasks
+		sequence, offset = saved.Sequence, saved.Offset
+		line += bytes.Count(content[:offset], []byte("\n"))
+	}
+	snapshotAt := sequence
+
+	for _, raw := range bytes.SplitAfter(content[offset:], []byte("\n")) {
+		if len(bytes.TrimSpace(raw)) == 0 {
+			offset += len(raw)
+			line++
+			continue
+		}
+
+		event := Event{}
+		err := json.Unmarshal(raw, &event)
+
+		if err != nil && !bytes.HasSuffix(raw, []byte("\n")) {
+			break
+		}
+
+		if err != nil {
+			return nil, 0, 0, 0, fmt.Errorf("invalid event log %s: line %d: %w", s.FileName, line, err)
+		}
+
+		if !until.IsZero() && !event.At.Before(until) {
+			break
+		}
+
+		tasks, err = applyEvent(tasks, event)
+
+		if err != nil {
+			return nil, 0, 0, 0, fmt.Errorf("invalid event log %s: line %d: %w", s.FileName, line, err)
+		}
+
+		sequence = event.Sequence
+		offset += len(raw)
+		line++
+	}
+	return tasks, sequence, snapshotAt, offset, nil
+}
+
+// readSnapshot returns the snapshot of the log content, unless it is
+// missing or belongs to another log.
+func (s *EventTaskStore) readSnapshot(content []byte) (*snapshot, bool) {
+	encoded, err := os.ReadFile(SnapshotFileName(s.FileName))
+
+	if err != nil {
+		return nil, false
+	}
+
+	saved := &snapshot{}
+	if json.Unmarshal(encoded, saved) != nil || saved.Offset > len(content) || saved.Offset < len(saved.Tail) {
+		return nil, false
+	}
+
+	if string(content[saved.Offset-len(saved.Tail):saved.Offset]) != saved.Tail {
+		return nil, false
+	}
+	return saved, true
+}
+
+func (s *EventTaskStore) writeSnapshot(at time.Time) error {
+	content, err := os.ReadFile(s.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	tail := content
+	if i := bytes.LastIndexByte(bytes.TrimSuffix(content, []byte("\n")), '\n'); i >= 0 {
+		tail = content[i+1:]
+	}
+
+	encoded, err := json.Marshal(snapshot{Sequence: s.sequence, Offset: len(content), Tail: string(tail), At: at, Tasks: s.Tasks})
+
+	if err != nil {
+		return err
+	}
+
+	temporary := SnapshotFileName(s.FileName) + ".tmp"
+	err = os.WriteFile(temporary, encoded, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	s.snapshotAt = s.sequence
+	return os.Rename(temporary, SnapshotFileName(s.FileName))
+}
+
+// append numbers the events, writes them to the log and applies them, then
+// takes a snapshot when SnapshotEvery events passed since the last one.
+func (s *EventTaskStore) append(events ...Event) error {
+	var buffer bytes.Buffer
+	now := time.Now()
+
+	if s.unterminated {
+		buffer.WriteByte('\n')
+	}
+
+	for i := range events {
+		s.sequence++
+		events[i].Sequence = s.sequence
+		events[i].At = now
+
+		encoded, err := json.Marshal(events[i])
+
+		if err != nil {
+			return err
+		}
+
+		buffer.Write(encoded)
+		buffer.WriteByte('\n')
+	}
+
+	if s.size > s.end {
+		err := os.Truncate(s.FileName, int64(s.end))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(s.FileName, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(buffer.Bytes())
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.end += buffer.Len()
+	s.size = s.end
+	s.unterminated = false
+	s.index = nil
+	for _, event := range events {
+		s.Tasks, err = applyEvent(s.Tasks, event)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.SnapshotEvery > 0 && s.sequence-s.snapshotAt >= s.SnapshotEvery {
+		return s.writeSnapshot(now)
+	}
+	return nil
+}
+
+func applyEvent(tasks []*models.Task, event Event) ([]*models.Task, error) {
+	i := slices.IndexFunc(tasks, func(task *models.Task) bool {
+		return task.Id == event.TaskId
+	})
+
+	if event.Type == TaskAdded {
+		if event.Task == nil || event.Task.Id != event.TaskId {
+			return nil, fmt.Errorf("event %d adds no task %d", event.Sequence, event.TaskId)
+		}
+		if i >= 0 {
+			tasks[i] = event.Task
+			return tasks, nil
+		}
+		return append(tasks, event.Task), nil
+	}
+
+	if i < 0 {
+		return nil, fmt.Errorf("event %d refers to missing task %d", event.Sequence, event.TaskId)
+	}
+
+	switch task := tasks[i]; event.Type {
+	case DescriptionUpdated:
+		updatedAt := event.At
+		task.Description = event.Description
+		task.UpdatedAt = &updatedAt
+	case StatusChanged:
+		updatedAt := event.At
+		task.Status = event.Status
+		task.UpdatedAt = &updatedAt
+		task.History = append(task.History, models.StatusChange{Status: event.Status, At: event.At})
+	case TaskRemoved:
+		return slices.Delete(tasks, i, i+1), nil
+	default:
+		return nil, fmt.Errorf("event %d has unknown type %q", event.Sequence, event.Type)
+	}
+	return tasks, nil
+}
+
+func (s *EventTaskStore) AddTask(task *models.Task) (*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	task.Id = 0
+	task.CreatedAt = time.Now()
+	task.Status = models.TODO
+	importTasks(slices.Clone(s.Tasks), []*models.Task{task})
+
+	return task, s.append(Event{Type: TaskAdded, TaskId: task.Id, Task: task})
+}
+
+func (s *EventTaskStore) ImportTasks(tasks []*models.Task) ([]*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	importTasks(slices.Clone(s.Tasks), tasks)
+
+	events := []Event{}
+	for _, task := range tasks {
+		events = append(events, Event{Type: TaskAdded, TaskId: task.Id, Task: task})
+	}
+
+	return tasks, s.append(events...)
+}
+
+func (s *EventTaskStore) RemoveTask(id int) (*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range s.Tasks {
+		if task.Id == id {
+			return task, s.append(Event{Type: TaskRemoved, TaskId: id})
+		}
+	}
+	return nil, fmt.Errorf("task with ID %d not found", id)
+}
+
+func (s *EventTaskStore) UpdateTask(id int, description string) error {
+	return s.change(id, Event{Type: DescriptionUpdated, TaskId: id, Description: description})
+}
+
+func (s *EventTaskStore) MarkInProgress(id int) error {
+	return s.change(id, Event{Type: StatusChanged, TaskId: id, Status: models.IN_PROGRESS})
+}
+
+func (s *EventTaskStore) MarkDone(id int) error {
+	return s.change(id, Event{Type: StatusChanged, TaskId: id, Status: models.DONE})
+}
+
+func (s *EventTaskStore) Query(query models.Query) ([]*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Apply(s.Tasks), nil
+}
+
+func (s *EventTaskStore) Search(terms string) ([]models.SearchResult, error) {
+	if s.index == nil {
+		err := s.loadFromFile()
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.index = newSearchIndex(s.Tasks)
+	}
+
+	return s.index.search(terms), nil
+}
+
+func (s *EventTaskStore) PrintAll() error {
+	return s.print("")
+}
+
+func (s *EventTaskStore) PrintTodo() error {
+	return s.print(models.TODO)
+}
+
+func (s *EventTaskStore) PrintDone() error {
+	return s.print(models.DONE)
+}
+
+func (s *EventTaskStore) PrintInProgress() error {
+	return s.print(models.IN_PROGRESS)
+}
+
+// Files leaves the snapshot out, it is rebuilt from the log when missing
+// or stale.
+func (s *EventTaskStore) Files() []string {
+	return []string{s.FileName}
+}
+
+// change appends event for the task with id, unless it would not change
+// its status.
+func (s *EventTaskStore) change(id int, event Event) error {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return err
+	}
+
+	for _, task := range s.Tasks {
+		if task.Id == id {
+			if event.Type == StatusChanged && task.Status == event.Status {
+				return nil
+			}
+			return s.append(event)
+		}
+	}
+	return fmt.Errorf("task with ID %d not found", id)
+}
+
+// print lists the tasks with status, all of them when status is empty.
+func (s *EventTaskStore) print(status models.Status) error {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return err
+	}
+
+	filteredTasks := models.Query{Filter: func(task *models.Task) bool {
+		return status == "" || task.Status == status
+	}}.Apply(s.Tasks)
+
+	if len(filteredTasks) == 0 {
+		fmt.Println(models.NoTaskString)
+		return nil
+	}
+
+	for _, task := range filteredTasks {
+		task.PrintTask()
+	}
+
+	if status == "" {
+		fmt.Printf("--------------- Total Tasks: %d ---------------\n", len(s.Tasks))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package stores
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventTaskStore(t *testing.T) {
+	asserts := assert.New(t)
+
+	t.Run("✅ Should append events and rebuild the tasks by replay", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.jsonl")
+		store := NewEventTaskStore(fileName)
+
+		store.AddTask(&models.Task{Description: "Write docs"})
+		store.AddTask(&models.Task{Description: "Ship it"})
+		asserts.Nil(store.UpdateTask(1, "Write the docs"))
+		asserts.Nil(store.MarkInProgress(1))
+		asserts.Nil(store.MarkInProgress(1))
+		_, err := store.RemoveTask(2)
+		asserts.Nil(err)
+
+		content, _ := os.ReadFile(fileName)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		asserts.Len(lines, 5)
+		asserts.Contains(lines[3], `"seq":4,"type":"StatusChanged"`)
+
+		tasks, err := NewEventTaskStore(fileName).Query(models.Query{})
+
+		asserts.Nil(err)
+		asserts.Len(tasks, 1)
+		asserts.Equal("Write the docs", tasks[0].Description)
+		asserts.Equal(models.IN_PROGRESS, tasks[0].Status)
+		asserts.Len(tasks[0].History, 1)
+		asserts.NotNil(tasks[0].UpdatedAt)
+	})
+
+	t.Run("✅ Should import tasks replacing the ones with the same ID", func(t *testing.T) {
+		store := NewEventTaskStore(filepath.Join(t.TempDir(), "tasks.jsonl"))
+		store.AddTask(&models.Task{Description: "First"})
+
+		imported, err := store.ImportTasks([]*models.Task{{Id: 1, Description: "Replaced"}, {Description: "New"}})
+
+		asserts.Nil(err)
+		asserts.Equal(2, imported[1].Id)
+		tasks, _ := store.Query(models.Query{})
+		asserts.Equal("Replaced", tasks[0].Description)
+		asserts.Len(tasks, 2)
+	})
+
+	t.Run("✅ Should replay from a snapshot and ignore a stale one", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.jsonl")
+		store := NewEventTaskStore(fileName)
+		store.SnapshotEvery = 2
+
+		store.AddTask(&models.Task{Description: "First"})
+		store.AddTask(&models.Task{Description: "Second"})
+		store.MarkDone(1)
+		asserts.FileExists(SnapshotFileName(fileName))
+
+		content, _ := os.ReadFile(fileName)
+		saved, ok := store.readSnapshot(content)
+		asserts.True(ok)
+		asserts.Equal(2, saved.Sequence)
+
+		tasks, err := NewEventTaskStore(fileName).Query(models.Query{})
+		asserts.Nil(err)
+		asserts.Len(tasks, 2)
+		asserts.Equal(models.DONE, tasks[0].Status)
+
+		lines := strings.SplitAfter(string(content), "\n")
+		os.WriteFile(fileName, []byte(lines[0]+strings.Replace(lines[1], "Second", "Other", 1)), 0644)
+
+		tasks, err = NewEventTaskStore(fileName).Query(models.Query{})
+		asserts.Nil(err)
+		asserts.Equal("Other", tasks[1].Description)
+		asserts.Equal(models.TODO, tasks[0].Status)
+	})
+
+	t.Run("✅ Should query the tasks as they were at a point in time", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.jsonl")
+		os.WriteFile(fileName, []byte(`{"seq":1,"type":"TaskAdded","at":"2024-08-19T09:00:00Z","task_id":1,"task":{"id":1,"description":"First","status":"To do","created_at":"2024-08-19T09:00:00Z","updated_at":null}}
+{"seq":2,"type":"StatusChanged","at":"2024-08-20T10:00:00Z","task_id":1,"status":"Done"}
+{"seq":3,"type":"TaskRemoved","at":"2024-08-21T10:00:00Z","task_id":1}
+`), 0644)
+		store := NewEventTaskStore(fileName)
+
+		tasks, err := store.AsOf(time.Date(2024, 8, 20, 0, 0, 0, 0, time.UTC))
+		asserts.Nil(err)
+		asserts.Equal(models.TODO, tasks[0].Status)
+
+		tasks, _ = store.AsOf(time.Date(2024, 8, 21, 0, 0, 0, 0, time.UTC))
+		asserts.Equal(models.DONE, tasks[0].Status)
+
+		tasks, _ = store.AsOf(time.Date(2024, 8, 22, 0, 0, 0, 0, time.UTC))
+		asserts.Empty(tasks)
+	})
+
+	t.Run("✅ Should ignore a torn last line and cut it on the next append", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.jsonl")
+		store := NewEventTaskStore(fileName)
+		store.AddTask(&models.Task{Description: "Write docs"})
+
+		file, _ := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY, 0644)
+		file.WriteString(`{"seq":2,"type":"StatusCha`)
+		file.Close()
+
+		store = NewEventTaskStore(fileName)
+		tasks, err := store.Query(models.Query{})
+		asserts.Nil(err)
+		asserts.Len(tasks, 1)
+		asserts.Equal(models.TODO, tasks[0].Status)
+
+		asserts.Nil(store.MarkDone(1))
+
+		content, _ := os.ReadFile(fileName)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		asserts.Len(lines, 2)
+		asserts.Contains(lines[1], `"seq":2,"type":"StatusChanged"`)
+
+		tasks, err = NewEventTaskStore(fileName).Query(models.Query{})
+		asserts.Nil(err)
+		asserts.Equal(models.DONE, tasks[0].Status)
+	})
+
+	t.Run("✅ Should append after a last event without newline", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.jsonl")
+		store := NewEventTaskStore(fileName)
+		store.AddTask(&models.Task{Description: "Write docs"})
+
+		content, _ := os.ReadFile(fileName)
+		os.WriteFile(fileName, content[:len(content)-1], 0644)
+
+		asserts.Nil(store.MarkDone(1))
+
+		tasks, err := NewEventTaskStore(fileName).Query(models.Query{})
+		asserts.Nil(err)
+		asserts.Equal(models.DONE, tasks[0].Status)
+	})
+
+	t.Run("❌ Should reject a broken event before the last line", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.jsonl")
+		store := NewEventTaskStore(fileName)
+		store.AddTask(&models.Task{Description: "Write docs"})
+
+		content, _ := os.ReadFile(fileName)
+		os.WriteFile(fileName, append([]byte(`{"seq":1,"type":"Task`+"\n"), content...), 0644)
+
+		_, err := NewEventTaskStore(fileName).Query(models.Query{})
+
+		asserts.ErrorContains(err, "line 1")
+	})
+
+	t.Run("❌ Should report the line of a broken event", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.jsonl")
+		os.WriteFile(fileName, []byte("\n{\"seq\":1,\"type\":\"TaskRemoved\",\"task_id\":4}\n"), 0644)
+
+		_, err := NewEventTaskStore(fileName).Query(models.Query{})
+
+		asserts.ErrorContains(err, "line 2: event 1 refers to missing task 4")
+	})
+}
@@ -19,6 +19,11 @@ func (tl *InMemoryTaskStore) AddTask(task *models.Task) (*models.Task, error) {
 	return task, nil
 }
 
+func (tl *InMemoryTaskStore) ImportTasks(tasks []*models.Task) ([]*models.Task, error) {
+	tl.Tasks = importTasks(tl.Tasks, tasks)
+	return tasks, nil
+}
+
 func (tl *InMemoryTaskStore) RemoveTask(id int) (*models.Task, error) {
 	for i, v := range tl.Tasks {
 		if v.Id == id {
@@ -42,6 +47,14 @@ func (tl *InMemoryTaskStore) UpdateTask(id int, description string) error {
 	return fmt.Errorf("task with ID %d not found", id)
 }
 
+func (tl *InMemoryTaskStore) Query(query models.Query) ([]*models.Task, error) {
+	return query.Apply(tl.Tasks), nil
+}
+
+func (tl *InMemoryTaskStore) Search(terms string) ([]models.SearchResult, error) {
+	return newSearchIndex(tl.Tasks).search(terms), nil
+}
+
 func (tl *InMemoryTaskStore) PrintAll() {
 	tl.printHasNoTasks()
 
@@ -11,10 +11,19 @@ import (
 type JsonTaskStore struct {
 	Tasks        []*models.Task
 	JsonFileName string
+	// BackupDir receives a rotating backup before tasks are removed or
+	// replaced, keeping the newest KeepBackups. Empty disables them.
+	BackupDir   string
+	KeepBackups int
+	meta        FileMeta
+	// checksum is the sha256 of the task file as last read or written, the
+	// saved search index is only used when it indexes that content.
+	checksum string
+	index    *searchIndex
 }
 
 func NewJsonTaskStore(jsonFileName string) *JsonTaskStore {
-	fileExistAndCreate(jsonFileName, &[]models.Task{})
+	fileExistAndCreate(jsonFileName, &taskFile{Version: CurrentVersion, Tasks: []*models.Task{}})
 
 	return &JsonTaskStore{
 		Tasks:        []*models.Task{},
@@ -23,35 +32,104 @@ func NewJsonTaskStore(jsonFileName string) *JsonTaskStore {
 }
 
 func (j *JsonTaskStore) saveToFile() error {
-	file, err := json.MarshalIndent(j.Tasks, "", " ")
+	updatedAt := time.Now()
+	j.meta.UpdatedAt = &updatedAt
+
+	tasks := j.Tasks
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
+
+	file, err := json.MarshalIndent(taskFile{Version: CurrentVersion, Tasks: tasks, Meta: j.meta}, "", " ")
 
 	if err != nil {
 		return err
 	}
 
+	index := j.index
+	if index == nil {
+		index, _ = readSearchIndex(SearchIndexFileName(j.JsonFileName), j.checksum)
+	}
+
 	err = os.WriteFile(j.JsonFileName, file, 0644)
 
 	if err != nil {
 		return err
 	}
-	return nil
+
+	j.checksum = checksum(file)
+	j.index = index
+
+	if index == nil {
+		return nil
+	}
+
+	index.sync(tasks)
+	return index.write(SearchIndexFileName(j.JsonFileName), j.checksum)
 }
 
+// loadFromFile reads the task file, upgrading and rewriting files of an
+// older version after backing them up.
 func (j *JsonTaskStore) loadFromFile() error {
-	file, err := os.ReadFile(j.JsonFileName)
+	content, err := os.ReadFile(j.JsonFileName)
 
 	if err != nil {
 		return err
 	}
 
-	err = json.Unmarshal(file, &j.Tasks)
+	file, version, err := migrate(content, time.Now())
 
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid task file %s: %w", j.JsonFileName, err)
+	}
+
+	if sum := checksum(content); sum != j.checksum {
+		j.index = nil
+		j.checksum = sum
+	}
+	j.Tasks = file.Tasks
+	j.meta = file.Meta
+
+	if version < CurrentVersion {
+		err = j.backup()
+
+		if err != nil {
+			return err
+		}
+
+		return j.saveToFile()
 	}
 	return nil
 }
 
+// Migrate upgrades the task file to the current version, or with dryRun
+// only reports the migrations it would apply.
+func (j *JsonTaskStore) Migrate(dryRun bool) (*MigrationReport, error) {
+	content, err := os.ReadFile(j.JsonFileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	version, pending, err := PendingMigrations(content)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid task file %s: %w", j.JsonFileName, err)
+	}
+
+	report := &MigrationReport{File: j.JsonFileName, Version: version, Current: CurrentVersion, Pending: []string{}}
+	for _, migration := range pending {
+		report.Pending = append(report.Pending, fmt.Sprintf("%d → %d: %s", migration.From, migration.From+1, migration.Description))
+	}
+
+	if dryRun || len(pending) == 0 {
+		return report, nil
+	}
+
+	report.Applied = true
+	return report, j.loadFromFile()
+}
+
 func (j *JsonTaskStore) AddTask(task *models.Task) (*models.Task, error) {
 	id, err := j.assignId()
 
@@ -74,11 +152,50 @@ func (j *JsonTaskStore) AddTask(task *models.Task) (*models.Task, error) {
 	return task, nil
 }
 
+// ImportTasks stores tasks keeping their fields. A task whose Id is already
+// taken replaces the stored task, any other keeps its Id when set or gets a
+// new one.
+func (j *JsonTaskStore) ImportTasks(tasks []*models.Task) ([]*models.Task, error) {
+	err := j.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.backup()
+
+	if err != nil {
+		return nil, err
+	}
+
+	j.Tasks = importTasks(j.Tasks, tasks)
+
+	err = j.saveToFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
 func (j *JsonTaskStore) RemoveTask(id int) (*models.Task, error) {
+	err := j.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
 	for i, v := range j.Tasks {
 		if v.Id == id {
+			err := j.backup()
+
+			if err != nil {
+				return nil, err
+			}
+
 			j.Tasks = append(j.Tasks[:i], j.Tasks[i+1:]...)
-			err := j.saveToFile()
+			err = j.saveToFile()
 
 			if err != nil {
 				return nil, err
@@ -113,6 +230,44 @@ func (j *JsonTaskStore) UpdateTask(id int, description string) error {
 	return fmt.Errorf("task with ID %d not found", id)
 }
 
+func (j *JsonTaskStore) Query(query models.Query) ([]*models.Task, error) {
+	err := j.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Apply(j.Tasks), nil
+}
+
+// Search looks terms up in the search index saved next to the task file,
+// building and saving it when missing or stale.
+func (j *JsonTaskStore) Search(terms string) ([]models.SearchResult, error) {
+	err := j.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if j.index == nil {
+		index, ok := readSearchIndex(SearchIndexFileName(j.JsonFileName), j.checksum)
+
+		if !ok {
+			index = newSearchIndex(j.Tasks)
+			err = index.write(SearchIndexFileName(j.JsonFileName), j.checksum)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		j.index = index
+	}
+
+	j.index.sync(j.Tasks)
+	return j.index.search(terms), nil
+}
+
 func (j *JsonTaskStore) printHasNoTasks() {
 	if len(j.Tasks) == 0 {
 		fmt.Println("No tasks found")
@@ -223,6 +378,12 @@ func (j *JsonTaskStore) PrintInProgress() error {
 }
 
 func (j *JsonTaskStore) MarkInProgress(id int) error {
+	err := j.loadFromFile()
+
+	if err != nil {
+		return err
+	}
+
 	for _, task := range j.Tasks {
 		if task.Id == id {
 			task.MarkAs(models.IN_PROGRESS)
@@ -239,6 +400,12 @@ func (j *JsonTaskStore) MarkInProgress(id int) error {
 }
 
 func (j *JsonTaskStore) MarkDone(id int) error {
+	err := j.loadFromFile()
+
+	if err != nil {
+		return err
+	}
+
 	for _, task := range j.Tasks {
 		if task.Id == id {
 			task.MarkAs(models.DONE)
@@ -253,6 +420,23 @@ func (j *JsonTaskStore) MarkDone(id int) error {
 	return fmt.Errorf("task with ID %d not found", id)
 }
 
+func (j *JsonTaskStore) Files() []string {
+	return []string{j.JsonFileName}
+}
+
+func (j *JsonTaskStore) backup() error {
+	if j.BackupDir == "" {
+		return nil
+	}
+
+	_, err := RotateBackup(j.Files(), j.BackupDir, max(j.KeepBackups, 1), time.Now())
+
+	if err != nil {
+		return fmt.Errorf("backup before change failed: %w", err)
+	}
+	return nil
+}
+
 func (j *JsonTaskStore) assignId() (int, error) {
 	err := j.loadFromFile()
 
@@ -273,6 +457,40 @@ func (j *JsonTaskStore) assignId() (int, error) {
 	return currentMax + 1, nil
 }
 
+func importTasks(stored []*models.Task, tasks []*models.Task) []*models.Task {
+	positions := map[int]int{}
+	nextId := 1
+	for i, task := range stored {
+		positions[task.Id] = i
+		nextId = max(nextId, task.Id+1)
+	}
+	for _, task := range tasks {
+		nextId = max(nextId, task.Id+1)
+	}
+
+	for _, task := range tasks {
+		if task.Status == "" {
+			task.Status = models.TODO
+		}
+		if task.CreatedAt.IsZero() {
+			task.CreatedAt = time.Now()
+		}
+
+		if i, ok := positions[task.Id]; ok && task.Id > 0 {
+			stored[i] = task
+			continue
+		}
+
+		if task.Id <= 0 {
+			task.Id = nextId
+			nextId++
+		}
+		positions[task.Id] = len(stored)
+		stored = append(stored, task)
+	}
+	return stored
+}
+
 func fileExistAndCreate(jsonFileName string, model any) {
 	if model == nil {
 		model = &[]models.Task{}
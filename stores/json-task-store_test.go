@@ -15,6 +15,7 @@ import (
 
 func setup() {
 	os.Remove("test.json")
+	os.Remove(SearchIndexFileName("test.json"))
 }
 
 func TestJsonTaskStore(t *testing.T) {
@@ -237,6 +238,25 @@ func TestJsonTaskStore(t *testing.T) {
 		asserts.Equal("No tasks found\n", result)
 	})
 
+	t.Run("✅ Should query the tasks matching a filter", func(t *testing.T) {
+		setup()
+
+		taskList := NewJsonTaskStore("test.json")
+		taskList.AddTask(createTask2(1))
+		taskList.AddTask(createTask2(2))
+		taskList.AddTask(createTask2(3))
+		taskList.MarkDone(2)
+
+		tasks, err := taskList.Query(models.Query{Filter: func(task *models.Task) bool {
+			return task.Status == models.TODO
+		}})
+
+		asserts.Nil(err)
+		asserts.Len(tasks, 2)
+		asserts.Equal(tasks[0].Id, 1)
+		asserts.Equal(tasks[1].Id, 3)
+	})
+
 	t.Run("✅ Should mark a task as in progress", func(t *testing.T) {
 		setup()
 
@@ -254,6 +274,45 @@ func TestJsonTaskStore(t *testing.T) {
 		taskList.AddTask(createTask2(1))
 		taskList.MarkDone(1)
 	})
+
+	t.Run("✅ Should import tasks keeping their fields", func(t *testing.T) {
+		setup()
+
+		taskList := NewJsonTaskStore("test.json")
+		taskList.AddTask(createTask2(1))
+		createdAt := time.Date(2024, 8, 20, 9, 0, 0, 0, time.UTC)
+
+		imported, err := taskList.ImportTasks([]*models.Task{
+			{Id: 1, Description: "Replaced", Status: models.DONE, CreatedAt: createdAt},
+			{Id: 7, Description: "Kept id"},
+			{Description: "New id", Status: models.IN_PROGRESS},
+		})
+
+		asserts.Nil(err)
+		asserts.Len(imported, 3)
+		asserts.Len(taskList.Tasks, 3)
+		asserts.Equal("Replaced", taskList.Tasks[0].Description)
+		asserts.Equal(models.DONE, taskList.Tasks[0].Status)
+		asserts.Equal(createdAt, taskList.Tasks[0].CreatedAt)
+		asserts.Equal(7, taskList.Tasks[1].Id)
+		asserts.Equal(models.TODO, taskList.Tasks[1].Status)
+		asserts.Equal(8, taskList.Tasks[2].Id)
+	})
+
+	t.Run("✅ Should import tasks edited after querying them", func(t *testing.T) {
+		setup()
+
+		taskList := NewJsonTaskStore("test.json")
+		taskList.AddTask(createTask2(1))
+
+		tasks, _ := taskList.Query(models.Query{})
+		tasks[0].Description = "Edited"
+		_, err := taskList.ImportTasks(tasks)
+
+		asserts.Nil(err)
+		asserts.Nil(taskList.loadFromFile())
+		asserts.Equal("Edited", taskList.Tasks[0].Description)
+	})
 }
 
 func joinMessage2(tasks *JsonTaskStore) string {
@@ -0,0 +1,808 @@
+package stores
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"task-tracker/models"
+	"time"
+)
+
+const (
+	kvMagic         = "TTKV0001"
+	kvPut      byte = 1
+	kvDelete   byte = 2
+	kvHeader        = 8
+	kvNoDueDay      = int64(0)
+)
+
+// CompactAbove is how many bytes of replaced or removed records a kv store
+// keeps before compacting, once they also outweigh the live ones.
+const CompactAbove = 1 << 20
+
+var errTornRecord = errors.New("torn record")
+
+type (
+	// KVTaskStore keeps tasks in a single log-structured file. Every change
+	// appends a checksummed record with the task, or a tombstone when it
+	// is removed, and the last record of a task wins. Records carry the
+	// indexed fields next to the task, so opening the store reads no task
+	// until one is asked for: status, due date, tags and project. Tasks are
+	// kept in ID order.
+	KVTaskStore struct {
+		FileName  string
+		data      []byte
+		size      int64
+		modTime   time.Time
+		entries   map[int]*kvEntry
+		byStatus  map[models.Status]map[int]bool
+		byTag     map[string]map[int]bool
+		byProject map[string]map[int]bool
+		ids       []int
+		byDue     []int
+		cache     map[int]*models.Task
+		live      int
+		dead      int
+		index     *searchIndex
+	}
+
+	// kvEntry locates the last record of a task in the file.
+	kvEntry struct {
+		offset  int
+		size    int
+		value   int
+		status  models.Status
+		due     int64
+		tags    []string
+		project string
+	}
+
+	kvRecord struct {
+		op      byte
+		id      int
+		status  models.Status
+		due     int64
+		tags    []string
+		project string
+		value   int
+	}
+
+	kvReader struct {
+		data   []byte
+		offset int
+		err    error
+	}
+)
+
+func NewKVTaskStore(fileName string) *KVTaskStore {
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		err := os.WriteFile(fileName, []byte(kvMagic), 0644)
+
+		if err != nil {
+			panic("Error creating file: " + err.Error())
+		}
+	}
+
+	return &KVTaskStore{FileName: fileName}
+}
+
+// load scans the record headers of the file, unless it did not change
+// since the last scan. A record cut short by a crash ends the log and is
+// dropped on the next write.
+func (s *KVTaskStore) load() error {
+	info, err := os.Stat(s.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	if s.entries != nil && info.Size() == s.size && info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(data, []byte(kvMagic)) {
+		return fmt.Errorf("invalid kv store %s: not a task file", s.FileName)
+	}
+
+	s.entries = map[int]*kvEntry{}
+	s.byStatus = map[models.Status]map[int]bool{}
+	s.byTag = map[string]map[int]bool{}
+	s.byProject = map[string]map[int]bool{}
+	s.cache = map[int]*models.Task{}
+	s.live, s.dead = 0, 0
+	s.ids, s.byDue, s.index = nil, nil, nil
+
+	offset := len(kvMagic)
+	for offset < len(data) {
+		record, size, err := decodeKVRecord(data[offset:])
+
+		if errors.Is(err, errTornRecord) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid kv store %s: record at offset %d: %w", s.FileName, offset, err)
+		}
+
+		s.apply(record, offset, size)
+		offset += size
+	}
+
+	s.data = data[:offset]
+	s.size = info.Size()
+	s.modTime = info.ModTime()
+	return nil
+}
+
+func (s *KVTaskStore) apply(record kvRecord, offset int, size int) {
+	if old, ok := s.entries[record.id]; ok {
+		delete(s.byStatus[old.status], record.id)
+		for _, tag := range old.tags {
+			delete(s.byTag[strings.ToLower(tag)], record.id)
+		}
+		delete(s.byProject[strings.ToLower(old.project)], record.id)
+		s.live -= old.size
+		s.dead += old.size
+	}
+
+	delete(s.cache, record.id)
+	s.ids, s.byDue, s.index = nil, nil, nil
+
+	if record.op == kvDelete {
+		delete(s.entries, record.id)
+		s.dead += size
+		return
+	}
+
+	s.entries[record.id] = &kvEntry{offset, size, offset + record.value, record.status, record.due, record.tags, record.project}
+	s.live += size
+
+	if s.byStatus[record.status] == nil {
+		s.byStatus[record.status] = map[int]bool{}
+	}
+	s.byStatus[record.status][record.id] = true
+
+	for _, tag := range record.tags {
+		key := strings.ToLower(tag)
+		if s.byTag[key] == nil {
+			s.byTag[key] = map[int]bool{}
+		}
+		s.byTag[key][record.id] = true
+	}
+
+	project := strings.ToLower(record.project)
+	if s.byProject[project] == nil {
+		s.byProject[project] = map[int]bool{}
+	}
+	s.byProject[project][record.id] = true
+}
+
+// write appends the records to the file and applies them, compacting the
+// file when it holds more dead records than live ones.
+func (s *KVTaskStore) write(records ...[]byte) error {
+	if s.size > int64(len(s.data)) {
+		err := os.Truncate(s.FileName, int64(len(s.data)))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(s.FileName, os.O_APPEND|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(bytes.Join(records, nil))
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, encoded := range records {
+		record, size, err := decodeKVRecord(encoded)
+
+		if err != nil {
+			return err
+		}
+
+		s.apply(record, len(s.data), size)
+		s.data = append(s.data, encoded...)
+	}
+
+	if s.dead > CompactAbove && s.dead > s.live {
+		return s.Compact()
+	}
+	return s.stat()
+}
+
+func (s *KVTaskStore) stat() error {
+	info, err := os.Stat(s.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	s.size = info.Size()
+	s.modTime = info.ModTime()
+	return nil
+}
+
+// Compact rewrites the file with only the last record of each task.
+func (s *KVTaskStore) Compact() error {
+	err := s.load()
+
+	if err != nil {
+		return err
+	}
+
+	data := []byte(kvMagic)
+	for _, id := range s.sortedIds() {
+		entry := s.entries[id]
+		data = append(data, s.data[entry.offset:entry.offset+entry.size]...)
+	}
+
+	temporary := s.FileName + ".tmp"
+	err = os.WriteFile(temporary, data, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(temporary, s.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	s.entries = nil
+	return s.load()
+}
+
+func (s *KVTaskStore) sortedIds() []int {
+	if s.ids == nil {
+		s.ids = make([]int, 0, len(s.entries))
+		for id := range s.entries {
+			s.ids = append(s.ids, id)
+		}
+		slices.Sort(s.ids)
+	}
+	return s.ids
+}
+
+// task decodes the stored task with id, or returns nil without one.
+func (s *KVTaskStore) task(id int) (*models.Task, error) {
+	if task, ok := s.cache[id]; ok {
+		return task, nil
+	}
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, nil
+	}
+
+	length := entry.offset + entry.size - entry.value
+	task := &models.Task{}
+	err := json.Unmarshal(s.data[entry.value:entry.value+length], task)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid kv store %s: task %d: %w", s.FileName, id, err)
+	}
+
+	s.cache[id] = task
+	return task, nil
+}
+
+func (s *KVTaskStore) tasks(ids []int) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0, len(ids))
+	for _, id := range ids {
+		task, err := s.task(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// indexed loads the tasks whose IDs are in the index set.
+func (s *KVTaskStore) indexed(set map[int]bool) ([]*models.Task, error) {
+	ids := make([]int, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return s.tasks(ids)
+}
+
+// WithStatus returns the tasks with status through the status index.
+func (s *KVTaskStore) WithStatus(status models.Status) ([]*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.indexed(s.byStatus[status])
+}
+
+// WithTag returns the tasks tagged with tag, in any case, through the tag
+// index.
+func (s *KVTaskStore) WithTag(tag string) ([]*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.indexed(s.byTag[strings.ToLower(tag)])
+}
+
+// WithProject returns the tasks of project, in any case, through the
+// project index. An empty project returns the tasks without one.
+func (s *KVTaskStore) WithProject(project string) ([]*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.indexed(s.byProject[strings.ToLower(project)])
+}
+
+// DueBetween returns the tasks due from from up to before to, earliest
+// first, through the due date index.
+func (s *KVTaskStore) DueBetween(from time.Time, to time.Time) ([]*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tasks(s.dueBetween(from.UnixNano(), to.UnixNano()))
+}
+
+// dueBetween returns the IDs of the tasks due from from up to before to, in
+// Unix nanoseconds, earliest first.
+func (s *KVTaskStore) dueBetween(from int64, to int64) []int {
+	if s.byDue == nil {
+		s.byDue = []int{}
+		for _, id := range s.sortedIds() {
+			if s.entries[id].due != kvNoDueDay {
+				s.byDue = append(s.byDue, id)
+			}
+		}
+		sort.SliceStable(s.byDue, func(i, j int) bool {
+			return s.entries[s.byDue[i]].due < s.entries[s.byDue[j]].due
+		})
+	}
+
+	start := sort.Search(len(s.byDue), func(i int) bool { return s.entries[s.byDue[i]].due >= from })
+	end := sort.Search(len(s.byDue), func(i int) bool { return s.entries[s.byDue[i]].due >= to })
+	return s.byDue[start:max(start, end)]
+}
+
+func (s *KVTaskStore) AddTask(task *models.Task) (*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	task.Id = 0
+	task.CreatedAt = time.Now()
+	task.Status = models.TODO
+
+	_, err = s.ImportTasks([]*models.Task{task})
+	return task, err
+}
+
+func (s *KVTaskStore) ImportTasks(tasks []*models.Task) ([]*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stored := []*models.Task{}
+	if ids := s.sortedIds(); len(ids) > 0 {
+		stored = append(stored, &models.Task{Id: ids[len(ids)-1]})
+	}
+
+	for _, task := range tasks {
+		if _, ok := s.entries[task.Id]; ok {
+			stored = append(stored, &models.Task{Id: task.Id})
+		}
+	}
+	importTasks(stored, tasks)
+
+	records := [][]byte{}
+	for _, task := range tasks {
+		record, err := encodeKVRecord(kvPut, task.Id, task)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return tasks, s.write(records...)
+}
+
+func (s *KVTaskStore) RemoveTask(id int) (*models.Task, error) {
+	task, err := s.find(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := encodeKVRecord(kvDelete, id, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return task, s.write(record)
+}
+
+func (s *KVTaskStore) UpdateTask(id int, description string) error {
+	return s.update(id, func(task *models.Task) {
+		updatedTime := time.Now()
+		task.UpdatedAt = &updatedTime
+		task.Description = description
+	})
+}
+
+func (s *KVTaskStore) MarkInProgress(id int) error {
+	return s.update(id, func(task *models.Task) {
+		task.MarkAs(models.IN_PROGRESS)
+	})
+}
+
+func (s *KVTaskStore) MarkDone(id int) error {
+	return s.update(id, func(task *models.Task) {
+		task.MarkAs(models.DONE)
+	})
+}
+
+// Query decodes only the tasks the indexes allow for the Where of query,
+// then filters them.
+func (s *KVTaskStore) Query(query models.Query) ([]*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.tasks(s.candidates(query.Where))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Apply(tasks), nil
+}
+
+// candidates returns, in ID order, the IDs of the tasks matching every
+// predicate of where according to the indexes.
+func (s *KVTaskStore) candidates(where models.Where) []int {
+	if where.IsZero() {
+		return s.sortedIds()
+	}
+
+	sets := []map[int]bool{}
+	union := func(index map[string]map[int]bool, keys []string) {
+		set := map[int]bool{}
+		for _, key := range keys {
+			for id := range index[strings.ToLower(key)] {
+				set[id] = true
+			}
+		}
+		sets = append(sets, set)
+	}
+
+	if where.Statuses != nil {
+		set := map[int]bool{}
+		for _, status := range where.Statuses {
+			for id := range s.byStatus[status] {
+				set[id] = true
+			}
+		}
+		sets = append(sets, set)
+	}
+	if where.Tags != nil {
+		union(s.byTag, where.Tags)
+	}
+	if where.Projects != nil {
+		union(s.byProject, where.Projects)
+	}
+	if where.DueFrom != nil || where.DueBefore != nil {
+		from, to := int64(math.MinInt64), int64(math.MaxInt64)
+		if where.DueFrom != nil {
+			from = where.DueFrom.UnixNano()
+		}
+		if where.DueBefore != nil {
+			to = where.DueBefore.UnixNano()
+		}
+
+		set := map[int]bool{}
+		for _, id := range s.dueBetween(from, to) {
+			set[id] = true
+		}
+		sets = append(sets, set)
+	}
+
+	slices.SortFunc(sets, func(a, b map[int]bool) int {
+		return len(a) - len(b)
+	})
+
+	ids := []int{}
+	for id := range sets[0] {
+		if !slices.ContainsFunc(sets[1:], func(set map[int]bool) bool { return !set[id] }) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (s *KVTaskStore) Search(terms string) ([]models.SearchResult, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if s.index == nil {
+		tasks, err := s.tasks(s.sortedIds())
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.index = newSearchIndex(tasks)
+	}
+
+	return s.index.search(terms), nil
+}
+
+func (s *KVTaskStore) PrintAll() error {
+	return s.print("")
+}
+
+func (s *KVTaskStore) PrintTodo() error {
+	return s.print(models.TODO)
+}
+
+func (s *KVTaskStore) PrintDone() error {
+	return s.print(models.DONE)
+}
+
+func (s *KVTaskStore) PrintInProgress() error {
+	return s.print(models.IN_PROGRESS)
+}
+
+func (s *KVTaskStore) Files() []string {
+	return []string{s.FileName}
+}
+
+func (s *KVTaskStore) find(id int) (*models.Task, error) {
+	err := s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.task(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if task == nil {
+		return nil, fmt.Errorf("task with ID %d not found", id)
+	}
+	return task, nil
+}
+
+func (s *KVTaskStore) update(id int, change func(*models.Task)) error {
+	task, err := s.find(id)
+
+	if err != nil {
+		return err
+	}
+
+	change(task)
+	record, err := encodeKVRecord(kvPut, id, task)
+
+	if err != nil {
+		return err
+	}
+
+	return s.write(record)
+}
+
+// print lists the tasks with status, all of them when status is empty.
+func (s *KVTaskStore) print(status models.Status) error {
+	var (
+		tasks []*models.Task
+		err   error
+	)
+
+	if status == "" {
+		tasks, err = s.Query(models.Query{})
+	} else {
+		tasks, err = s.WithStatus(status)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println(models.NoTaskString)
+		return nil
+	}
+
+	for _, task := range tasks {
+		task.PrintTask()
+	}
+
+	if status == "" {
+		fmt.Printf("--------------- Total Tasks: %d ---------------\n", len(s.entries))
+	}
+	return nil
+}
+
+// encodeKVRecord lays out a record as its checksum and length, then the
+// operation, task ID, indexed fields and the task as JSON.
+func encodeKVRecord(op byte, id int, task *models.Task) ([]byte, error) {
+	body := []byte{op}
+	body = binary.AppendVarint(body, int64(id))
+
+	var value []byte
+	var status models.Status
+	var project string
+	due := kvNoDueDay
+	tags := []string{}
+
+	if task != nil {
+		encoded, err := json.Marshal(task)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, status, tags, project = encoded, task.Status, task.Tags, task.Project
+		if task.Due != nil {
+			due = task.Due.UnixNano()
+		}
+	}
+
+	body = appendKVString(body, status.String())
+	body = binary.AppendVarint(body, due)
+	body = binary.AppendUvarint(body, uint64(len(tags)))
+	for _, tag := range tags {
+		body = appendKVString(body, tag)
+	}
+	body = appendKVString(body, project)
+	body = binary.AppendUvarint(body, uint64(len(value)))
+	body = append(body, value...)
+
+	record := binary.LittleEndian.AppendUint32(nil, crc32.ChecksumIEEE(body))
+	record = binary.LittleEndian.AppendUint32(record, uint32(len(body)))
+	return append(record, body...), nil
+}
+
+// decodeKVRecord reads the record data starts with and returns its size.
+// A record running past the end of data, or the last one failing its
+// checksum, is torn.
+func decodeKVRecord(data []byte) (kvRecord, int, error) {
+	record := kvRecord{}
+	if len(data) < kvHeader {
+		return record, 0, errTornRecord
+	}
+
+	size := kvHeader + int(binary.LittleEndian.Uint32(data[4:]))
+	if size > len(data) {
+		return record, 0, errTornRecord
+	}
+
+	body := data[kvHeader:size]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(data) {
+		if size == len(data) {
+			return record, 0, errTornRecord
+		}
+		return record, 0, fmt.Errorf("checksum mismatch")
+	}
+
+	reader := &kvReader{data: body}
+	record.op = reader.byte()
+	record.id = int(reader.varint())
+	record.status = models.Status(reader.string())
+	record.due = reader.varint()
+	for count := reader.uvarint(); count > 0 && reader.err == nil; count-- {
+		record.tags = append(record.tags, reader.string())
+	}
+	record.project = reader.string()
+	length := int(reader.uvarint())
+	record.value = kvHeader + reader.offset
+	reader.offset += length
+
+	if reader.err != nil || reader.offset != len(body) || (record.op != kvPut && record.op != kvDelete) {
+		return record, 0, fmt.Errorf("malformed record")
+	}
+	return record, size, nil
+}
+
+func appendKVString(data []byte, value string) []byte {
+	data = binary.AppendUvarint(data, uint64(len(value)))
+	return append(data, value...)
+}
+
+func (r *kvReader) byte() byte {
+	if r.err != nil || r.offset >= len(r.data) {
+		r.err = errTornRecord
+		return 0
+	}
+	r.offset++
+	return r.data[r.offset-1]
+}
+
+func (r *kvReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	value, n := binary.Varint(r.data[r.offset:])
+	if n <= 0 {
+		r.err = errTornRecord
+		return 0
+	}
+	r.offset += n
+	return value
+}
+
+func (r *kvReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	value, n := binary.Uvarint(r.data[r.offset:])
+	if n <= 0 {
+		r.err = errTornRecord
+		return 0
+	}
+	r.offset += n
+	return value
+}
+
+func (r *kvReader) string() string {
+	length := int(r.uvarint())
+	if r.err != nil || length > len(r.data)-r.offset {
+		r.err = errTornRecord
+		return ""
+	}
+	r.offset += length
+	return string(r.data[r.offset-length : r.offset])
+}
@@ -0,0 +1,244 @@
+package stores
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"task-tracker/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKVTaskStore(t *testing.T) {
+	asserts := assert.New(t)
+	due := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("✅ Should add, change and reload tasks", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.kv")
+		store := NewKVTaskStore(fileName)
+
+		store.AddTask(&models.Task{Description: "Write docs", Tags: []string{"Docs"}, Due: &due})
+		store.AddTask(&models.Task{Description: "Ship it"})
+		asserts.Nil(store.UpdateTask(1, "Write the docs"))
+		asserts.Nil(store.MarkDone(2))
+		_, err := store.RemoveTask(3)
+		asserts.EqualError(err, "task with ID 3 not found")
+
+		tasks, err := NewKVTaskStore(fileName).Query(models.Query{})
+
+		asserts.Nil(err)
+		asserts.Len(tasks, 2)
+		asserts.Equal("Write the docs", tasks[0].Description)
+		asserts.Equal(models.DONE, tasks[1].Status)
+		asserts.Len(tasks[1].History, 1)
+	})
+
+	t.Run("✅ Should look tasks up through the indexes", func(t *testing.T) {
+		store := NewKVTaskStore(filepath.Join(t.TempDir(), "tasks.kv"))
+		later := due.AddDate(0, 0, 7)
+		store.ImportTasks([]*models.Task{
+			{Description: "First", Tags: []string{"api"}, Due: &later},
+			{Description: "Second", Tags: []string{"API", "web"}, Due: &due},
+			{Description: "Third"},
+		})
+		store.MarkInProgress(2)
+
+		tasks, _ := store.WithStatus(models.TODO)
+		asserts.Equal([]int{1, 3}, ids(tasks))
+
+		tasks, _ = store.WithTag("api")
+		asserts.Equal([]int{1, 2}, ids(tasks))
+
+		tasks, _ = store.DueBetween(due, due.AddDate(0, 1, 0))
+		asserts.Equal([]int{2, 1}, ids(tasks))
+
+		tasks, _ = store.DueBetween(due.AddDate(0, 0, 1), later)
+		asserts.Empty(tasks)
+	})
+
+	t.Run("✅ Should look tasks up by project", func(t *testing.T) {
+		store := NewKVTaskStore(filepath.Join(t.TempDir(), "tasks.kv"))
+		store.ImportTasks([]*models.Task{
+			{Description: "First", Project: "Web"},
+			{Description: "Second", Project: "mobile"},
+			{Description: "Third"},
+		})
+		_, err := store.ImportTasks([]*models.Task{{Id: 2, Description: "Second", Project: "web"}})
+		asserts.Nil(err)
+
+		tasks, _ := store.WithProject("WEB")
+		asserts.Equal([]int{1, 2}, ids(tasks))
+
+		tasks, _ = store.WithProject("mobile")
+		asserts.Empty(tasks)
+
+		tasks, _ = store.WithProject("")
+		asserts.Equal([]int{3}, ids(tasks))
+	})
+
+	t.Run("✅ Should only decode the tasks the indexes allow for a query", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.kv")
+		later := due.AddDate(0, 0, 7)
+		NewKVTaskStore(fileName).ImportTasks([]*models.Task{
+			{Description: "First", Tags: []string{"api"}, Project: "web", Due: &later},
+			{Description: "Second", Tags: []string{"api"}, Project: "web", Due: &due},
+			{Description: "Third", Project: "web"},
+			{Description: "Fourth", Status: models.DONE, Tags: []string{"api"}},
+		})
+
+		store := NewKVTaskStore(fileName)
+		tasks, err := store.Query(models.Query{
+			Where: models.Where{Statuses: []models.Status{models.TODO}, Tags: []string{"API"}, Projects: []string{"web"}, DueBefore: &later},
+			Filter: func(task *models.Task) bool {
+				return task.Due != nil && task.Due.Before(later)
+			},
+		})
+
+		asserts.Nil(err)
+		asserts.Equal([]int{2}, ids(tasks))
+		asserts.Len(store.cache, 1)
+
+		tasks, _ = NewKVTaskStore(fileName).Query(models.Query{Where: models.Where{Tags: []string{"api"}}})
+		asserts.Equal([]int{1, 2, 4}, ids(tasks))
+	})
+
+	t.Run("✅ Should compact replaced and removed records", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.kv")
+		store := NewKVTaskStore(fileName)
+		store.AddTask(&models.Task{Description: "Keep"})
+		store.AddTask(&models.Task{Description: "Remove"})
+		store.UpdateTask(1, "Kept")
+		store.RemoveTask(2)
+
+		before, _ := os.Stat(fileName)
+		asserts.Nil(store.Compact())
+		after, _ := os.Stat(fileName)
+
+		asserts.Less(after.Size(), before.Size())
+		tasks, _ := NewKVTaskStore(fileName).Query(models.Query{})
+		asserts.Equal([]int{1}, ids(tasks))
+		asserts.Equal("Kept", tasks[0].Description)
+	})
+
+	t.Run("✅ Should drop a record torn by a crash", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.kv")
+		NewKVTaskStore(fileName).AddTask(&models.Task{Description: "First"})
+		content, _ := os.ReadFile(fileName)
+		os.WriteFile(fileName, append(content, content[len(kvMagic):len(content)-3]...), 0644)
+
+		store := NewKVTaskStore(fileName)
+		_, err := store.AddTask(&models.Task{Description: "Second"})
+		asserts.Nil(err)
+
+		tasks, err := NewKVTaskStore(fileName).Query(models.Query{})
+		asserts.Nil(err)
+		asserts.Equal([]int{1, 2}, ids(tasks))
+	})
+
+	t.Run("❌ Should report a corrupt record", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.kv")
+		store := NewKVTaskStore(fileName)
+		store.AddTask(&models.Task{Description: "First"})
+		store.AddTask(&models.Task{Description: "Second"})
+		content, _ := os.ReadFile(fileName)
+		content[len(kvMagic)+12] ^= 0xff
+		os.WriteFile(fileName, content, 0644)
+
+		_, err := NewKVTaskStore(fileName).Query(models.Query{})
+
+		asserts.ErrorContains(err, "record at offset 8: checksum mismatch")
+	})
+}
+
+func ids(tasks []*models.Task) []int {
+	result := []int{}
+	for _, task := range tasks {
+		result = append(result, task.Id)
+	}
+	return result
+}
+
+// BenchmarkTaskStores compares opening, querying and changing 100k tasks in
+// the json and kv stores, each iteration standing for one command.
+func BenchmarkTaskStores(b *testing.B) {
+	const count = 100_000
+	due := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	createTasks := func() []*models.Task {
+		tasks := make([]*models.Task, 0, count)
+		for i := range count {
+			task := &models.Task{Description: fmt.Sprintf("Task number %d", i), Project: fmt.Sprintf("Project %d", i%50)}
+			if i%3 == 0 {
+				task.Status = models.DONE
+			}
+			if i%10 == 0 {
+				day := due.AddDate(0, 0, i%365)
+				task.Due = &day
+			}
+			tasks = append(tasks, task)
+		}
+		return tasks
+	}
+
+	dir := b.TempDir()
+	jsonFile, kvFile := filepath.Join(dir, "tasks.json"), filepath.Join(dir, "tasks.kv")
+	if _, err := NewJsonTaskStore(jsonFile).ImportTasks(createTasks()); err != nil {
+		b.Fatal(err)
+	}
+	if _, err := NewKVTaskStore(kvFile).ImportTasks(createTasks()); err != nil {
+		b.Fatal(err)
+	}
+
+	todo := models.Query{
+		Filter: func(task *models.Task) bool { return task.Status == models.TODO },
+		Where:  models.Where{Statuses: []models.Status{models.TODO}},
+	}
+	project := models.Query{
+		Filter: func(task *models.Task) bool { return strings.EqualFold(task.Project, "Project 7") },
+		Where:  models.Where{Projects: []string{"Project 7"}},
+	}
+
+	b.Run("json/list todo", func(b *testing.B) {
+		for range b.N {
+			NewJsonTaskStore(jsonFile).Query(todo)
+		}
+	})
+	b.Run("kv/list todo", func(b *testing.B) {
+		for range b.N {
+			NewKVTaskStore(kvFile).Query(todo)
+		}
+	})
+	b.Run("json/list project", func(b *testing.B) {
+		for range b.N {
+			NewJsonTaskStore(jsonFile).Query(project)
+		}
+	})
+	b.Run("kv/list project", func(b *testing.B) {
+		for range b.N {
+			NewKVTaskStore(kvFile).Query(project)
+		}
+	})
+	b.Run("json/list all", func(b *testing.B) {
+		for range b.N {
+			NewJsonTaskStore(jsonFile).Query(models.Query{})
+		}
+	})
+	b.Run("kv/list all", func(b *testing.B) {
+		for range b.N {
+			NewKVTaskStore(kvFile).Query(models.Query{})
+		}
+	})
+	b.Run("json/mark done", func(b *testing.B) {
+		for i := range b.N {
+			NewJsonTaskStore(jsonFile).MarkDone(i%count + 1)
+		}
+	})
+	b.Run("kv/mark done", func(b *testing.B) {
+		for i := range b.N {
+			NewKVTaskStore(kvFile).MarkDone(i%count + 1)
+		}
+	})
+}
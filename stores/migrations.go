@@ -0,0 +1,140 @@
+package stores
+
+import (
+	"encoding/json"
+	"fmt"
+	"task-tracker/models"
+	"time"
+)
+
+// CurrentVersion is the version of the task file this build writes.
+const CurrentVersion = 2
+
+type (
+	// taskFile is the envelope of the task file since version 2.
+	taskFile struct {
+		Version int            `json:"version"`
+		Tasks   []*models.Task `json:"tasks"`
+		Meta    FileMeta       `json:"meta"`
+	}
+
+	FileMeta struct {
+		UpdatedAt *time.Time        `json:"updated_at,omitempty"`
+		Migrated  []MigrationRecord `json:"migrated,omitempty"`
+	}
+
+	MigrationRecord struct {
+		From int       `json:"from"`
+		To   int       `json:"to"`
+		At   time.Time `json:"at"`
+	}
+
+	MigrationReport struct {
+		File    string   `json:"file"`
+		Version int      `json:"version"`
+		Current int      `json:"current_version"`
+		Pending []string `json:"pending"`
+		Applied bool     `json:"applied"`
+	}
+
+	// Migration upgrades a decoded task file from version From to From+1.
+	Migration struct {
+		From        int
+		Description string
+		Apply       func(document any) (any, error)
+	}
+)
+
+// migrations is the registry of upgrades, one per version in order.
+var migrations = []Migration{
+	{
+		From:        1,
+		Description: "wrap the bare task array in a versioned envelope",
+		Apply: func(document any) (any, error) {
+			tasks, ok := document.([]any)
+			if !ok {
+				return nil, fmt.Errorf("expected a task array")
+			}
+			return map[string]any{"version": 2, "tasks": tasks, "meta": map[string]any{}}, nil
+		},
+	},
+}
+
+// fileVersion reads the version of a decoded task file, a bare array being
+// version 1.
+func fileVersion(document any) (int, error) {
+	switch value := document.(type) {
+	case []any:
+		return 1, nil
+	case map[string]any:
+		version, ok := value["version"].(float64)
+		if !ok || version < 1 || version != float64(int(version)) {
+			return 0, fmt.Errorf("missing or invalid version")
+		}
+		return int(version), nil
+	}
+	return 0, fmt.Errorf("expected a task array or a versioned object")
+}
+
+// PendingMigrations lists the migrations content needs to reach the
+// current version, and the version it has.
+func PendingMigrations(content []byte) (int, []Migration, error) {
+	var document any
+	err := json.Unmarshal(content, &document)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	version, err := fileVersion(document)
+	if err != nil {
+		return 0, nil, err
+	}
+	if version > CurrentVersion {
+		return version, nil, fmt.Errorf("task file version %d is newer than the supported %d, upgrade task-cli", version, CurrentVersion)
+	}
+
+	pending := []Migration{}
+	for _, migration := range migrations {
+		if migration.From >= version {
+			pending = append(pending, migration)
+		}
+	}
+	return version, pending, nil
+}
+
+// migrate decodes a task file of any known version, upgrading it in
+// memory. It reports the version the file had.
+func migrate(content []byte, now time.Time) (*taskFile, int, error) {
+	version, pending, err := PendingMigrations(content)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	file := &taskFile{}
+	if len(pending) == 0 {
+		err := json.Unmarshal(content, file)
+		return file, version, err
+	}
+
+	var document any
+	json.Unmarshal(content, &document)
+	for _, migration := range pending {
+		document, err = migration.Apply(document)
+		if err != nil {
+			return nil, 0, fmt.Errorf("migration from version %d failed: %w", migration.From, err)
+		}
+	}
+
+	upgraded, err := json.Marshal(document)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = json.Unmarshal(upgraded, file)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	file.Version = CurrentVersion
+	file.Meta.Migrated = append(file.Meta.Migrated, MigrationRecord{From: version, To: CurrentVersion, At: now})
+	return file, version, nil
+}
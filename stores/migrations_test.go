@@ -0,0 +1,64 @@
+package stores
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	asserts := assert.New(t)
+	now := time.Date(2024, 8, 28, 12, 0, 0, 0, time.UTC)
+	bareArray := []byte(`[{"id":1,"description":"Old task","status":"To do","created_at":"2024-08-20T09:00:00Z","updated_at":null}]`)
+
+	t.Run("✅ Should upgrade a bare task array", func(t *testing.T) {
+		file, version, err := migrate(bareArray, now)
+
+		asserts.NoError(err)
+		asserts.Equal(1, version)
+		asserts.Equal(CurrentVersion, file.Version)
+		asserts.Len(file.Tasks, 1)
+		asserts.Equal("Old task", file.Tasks[0].Description)
+		asserts.Equal([]MigrationRecord{{From: 1, To: CurrentVersion, At: now}}, file.Meta.Migrated)
+	})
+
+	t.Run("❌ Should refuse newer or unknown files", func(t *testing.T) {
+		_, _, err := PendingMigrations([]byte(`{"version": 99, "tasks": []}`))
+		asserts.ErrorContains(err, "newer than the supported")
+
+		_, _, err = PendingMigrations([]byte(`{"tasks": []}`))
+		asserts.EqualError(err, "missing or invalid version")
+	})
+
+	t.Run("✅ Should report and apply migrations of the task file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.json")
+		os.WriteFile(fileName, bareArray, 0644)
+		store := NewJsonTaskStore(fileName)
+
+		report, err := store.Migrate(true)
+		asserts.NoError(err)
+		asserts.False(report.Applied)
+		asserts.Equal([]string{"1 → 2: wrap the bare task array in a versioned envelope"}, report.Pending)
+
+		content, _ := os.ReadFile(fileName)
+		asserts.Equal(bareArray, content)
+
+		report, err = store.Migrate(false)
+		asserts.NoError(err)
+		asserts.True(report.Applied)
+
+		envelope := map[string]any{}
+		content, _ = os.ReadFile(fileName)
+		asserts.NoError(json.Unmarshal(content, &envelope))
+		asserts.Equal(float64(CurrentVersion), envelope["version"])
+		asserts.Len(envelope["tasks"], 1)
+
+		report, err = store.Migrate(false)
+		asserts.NoError(err)
+		asserts.Empty(report.Pending)
+	})
+}
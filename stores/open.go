@@ -0,0 +1,53 @@
+package stores
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"task-tracker/models"
+)
+
+// KeepBackups is how many automatic backups a store keeps.
+const KeepBackups = 10
+
+// BackupDir is where automatic backups of the store kept in path go.
+func BackupDir(path string) string {
+	return filepath.Join(filepath.Dir(path), ".backups")
+}
+
+// Open creates the store described by spec, a backend and a path such as
+// "json:tasks.json", "todotxt:todo.txt", "events:tasks.jsonl" or
+// "kv:tasks.kv". A bare path picks the backend from its extension.
+func Open(spec string) (models.TaskStore, error) {
+	backend, path, ok := strings.Cut(spec, ":")
+	if !ok {
+		backend, path = "json", spec
+		switch strings.ToLower(filepath.Ext(spec)) {
+		case ".txt":
+			backend = "todotxt"
+		case ".jsonl":
+			backend = "events"
+		case ".kv":
+			backend = "kv"
+		}
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("invalid store %q, expected backend:path", spec)
+	}
+
+	switch backend {
+	case "json":
+		store := NewJsonTaskStore(path)
+		store.BackupDir = BackupDir(path)
+		store.KeepBackups = KeepBackups
+		return store, nil
+	case "todotxt":
+		return NewTodoTxtTaskStore(path), nil
+	case "events":
+		return NewEventTaskStore(path), nil
+	case "kv":
+		return NewKVTaskStore(path), nil
+	}
+	return nil, fmt.Errorf("unknown store backend %q, expected: json, todotxt, events, kv", backend)
+}
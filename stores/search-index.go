@@ -0,0 +1,268 @@
+package stores
+
+import (
+	"cmp"
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"task-tracker/models"
+	"unicode"
+)
+
+// searchIndexVersion is bumped whenever the saved index layout changes.
+const searchIndexVersion = 2
+
+type (
+	// searchIndex is an inverted index over task descriptions, notes and
+	// tags. It keeps a hash of the text of each task, so sync only reindexes
+	// the tasks that changed.
+	searchIndex struct {
+		postings map[string]map[int][]int
+		lengths  map[int]int
+		hashes   map[int]uint64
+		tasks    map[int]*models.Task
+	}
+
+	// searchIndexFile is a saved index with the checksum of the task file
+	// it indexes.
+	searchIndexFile struct {
+		Version  int                      `json:"version"`
+		Checksum string                   `json:"checksum"`
+		Postings map[string]map[int][]int `json:"postings"`
+		Lengths  map[int]int              `json:"lengths"`
+		Hashes   map[int]uint64           `json:"hashes"`
+	}
+)
+
+// SearchIndexFileName is where the search index of the task file in
+// fileName is saved.
+func SearchIndexFileName(fileName string) string {
+	return fileName + ".index"
+}
+
+func newSearchIndex(tasks []*models.Task) *searchIndex {
+	index := &searchIndex{
+		postings: map[string]map[int][]int{},
+		lengths:  map[int]int{},
+		hashes:   map[int]uint64{},
+		tasks:    map[int]*models.Task{},
+	}
+
+	for _, task := range tasks {
+		index.add(task)
+	}
+	return index
+}
+
+func (s *searchIndex) add(task *models.Task) {
+	tokens := Tokenize(task.Description)
+	for _, field := range append([]string{task.Notes}, task.Tags...) {
+		if field == "" {
+			continue
+		}
+		// Leave a gap so phrases never match across fields.
+		tokens = append(tokens, "")
+		tokens = append(tokens, Tokenize(field)...)
+	}
+
+	s.tasks[task.Id] = task
+	s.lengths[task.Id] = len(tokens)
+	s.hashes[task.Id] = textHash(task)
+
+	for position, token := range tokens {
+		if token == "" {
+			continue
+		}
+		if s.postings[token] == nil {
+			s.postings[token] = map[int][]int{}
+		}
+		s.postings[token][task.Id] = append(s.postings[token][task.Id], position)
+	}
+}
+
+// remove drops the tasks with the given IDs in one pass over the postings.
+func (s *searchIndex) remove(ids map[int]bool) {
+	if len(ids) == 0 {
+		return
+	}
+
+	for token, postings := range s.postings {
+		for id := range ids {
+			delete(postings, id)
+		}
+		if len(postings) == 0 {
+			delete(s.postings, token)
+		}
+	}
+
+	for id := range ids {
+		delete(s.lengths, id)
+		delete(s.hashes, id)
+		delete(s.tasks, id)
+	}
+}
+
+// sync reindexes the tasks whose text changed and drops the removed ones.
+func (s *searchIndex) sync(tasks []*models.Task) {
+	seen := map[int]bool{}
+	changed := []*models.Task{}
+	for _, task := range tasks {
+		seen[task.Id] = true
+		if hash, ok := s.hashes[task.Id]; ok && hash == textHash(task) {
+			s.tasks[task.Id] = task
+			continue
+		}
+		changed = append(changed, task)
+	}
+
+	stale := map[int]bool{}
+	for id := range s.hashes {
+		if !seen[id] {
+			stale[id] = true
+		}
+	}
+	for _, task := range changed {
+		stale[task.Id] = true
+	}
+
+	s.remove(stale)
+	for _, task := range changed {
+		s.add(task)
+	}
+}
+
+// readSearchIndex reads the index saved in fileName, unless it is missing,
+// of another version or indexes another content than checksum.
+func readSearchIndex(fileName string, checksum string) (*searchIndex, bool) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, false
+	}
+
+	saved := searchIndexFile{}
+	if json.Unmarshal(content, &saved) != nil || saved.Version != searchIndexVersion || saved.Checksum != checksum {
+		return nil, false
+	}
+
+	index := &searchIndex{postings: saved.Postings, lengths: saved.Lengths, hashes: saved.Hashes, tasks: map[int]*models.Task{}}
+	if index.postings == nil || index.lengths == nil || index.hashes == nil {
+		return nil, false
+	}
+	return index, true
+}
+
+func (s *searchIndex) write(fileName string, checksum string) error {
+	content, err := json.Marshal(searchIndexFile{searchIndexVersion, checksum, s.postings, s.lengths, s.hashes})
+	if err != nil {
+		return err
+	}
+
+	temporary := fileName + ".tmp"
+	if err := os.WriteFile(temporary, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(temporary, fileName)
+}
+
+func textHash(task *models.Task) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(task.Description))
+	hash.Write([]byte{0})
+	hash.Write([]byte(task.Notes))
+	for _, tag := range task.Tags {
+		hash.Write([]byte{0})
+		hash.Write([]byte(tag))
+	}
+	return hash.Sum64()
+}
+
+func (s *searchIndex) search(query string) []models.SearchResult {
+	phrases := parseSearchQuery(query)
+	if len(phrases) == 0 {
+		return []models.SearchResult{}
+	}
+
+	scores := map[int]float64{}
+	matches := map[int][]string{}
+
+	for i, phrase := range phrases {
+		found := s.matchPhrase(phrase)
+		idf := 0.0
+		for _, token := range phrase {
+			idf += math.Log(1 + float64(len(s.tasks))/float64(len(s.postings[token])))
+		}
+
+		next := map[int]float64{}
+		for id, count := range found {
+			if _, ok := scores[id]; i > 0 && !ok {
+				continue
+			}
+			next[id] = scores[id] + float64(count)*idf/math.Sqrt(float64(s.lengths[id]))
+			matches[id] = append(matches[id], phrase...)
+		}
+		scores = next
+	}
+
+	results := []models.SearchResult{}
+	for id, score := range scores {
+		results = append(results, models.SearchResult{Task: s.tasks[id], Score: score, Matches: matches[id]})
+	}
+
+	slices.SortFunc(results, func(a, b models.SearchResult) int {
+		if result := cmp.Compare(b.Score, a.Score); result != 0 {
+			return result
+		}
+		return cmp.Compare(a.Task.Id, b.Task.Id)
+	})
+	return results
+}
+
+// matchPhrase returns how many times the phrase occurs in each task.
+func (s *searchIndex) matchPhrase(phrase []string) map[int]int {
+	found := map[int]int{}
+
+	for id, positions := range s.postings[phrase[0]] {
+		for _, start := range positions {
+			if s.phraseAt(phrase, id, start) {
+				found[id]++
+			}
+		}
+	}
+	return found
+}
+
+func (s *searchIndex) phraseAt(phrase []string, id int, start int) bool {
+	for offset, token := range phrase[1:] {
+		if !slices.Contains(s.postings[token][id], start+offset+1) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tokenize lowercases text and splits it into letters and digits runs.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func parseSearchQuery(query string) [][]string {
+	phrases := [][]string{}
+
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			if tokens := Tokenize(part); len(tokens) > 0 {
+				phrases = append(phrases, tokens)
+			}
+			continue
+		}
+		for _, token := range Tokenize(part) {
+			phrases = append(phrases, []string{token})
+		}
+	}
+	return phrases
+}
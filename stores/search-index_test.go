@@ -0,0 +1,129 @@
+package stores
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"task-tracker/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchIndex(t *testing.T) {
+	asserts := assert.New(t)
+
+	createTasks := func() []*models.Task {
+		tasks := []*models.Task{
+			createTask(1, models.TODO),
+			createTask(2, models.TODO),
+			createTask(3, models.DONE),
+		}
+		tasks[0].Description = "Buy milk and bread"
+		tasks[1].Description = "Milk the cow, then buy a new bucket"
+		tasks[2].Description = "Deploy the API"
+		tasks[2].Tags = []string{"Backend", "milk"}
+		return tasks
+	}
+
+	t.Run("✅ Should find tokens case-insensitively across descriptions and tags", func(t *testing.T) {
+		results := newSearchIndex(createTasks()).search("MILK")
+
+		asserts.Len(results, 3)
+	})
+
+	t.Run("✅ Should rank shorter and denser matches first", func(t *testing.T) {
+		results := newSearchIndex(createTasks()).search("buy milk")
+
+		asserts.Len(results, 2)
+		asserts.Equal(1, results[0].Task.Id)
+		asserts.Equal(2, results[1].Task.Id)
+		asserts.Greater(results[0].Score, results[1].Score)
+	})
+
+	t.Run("✅ Should only match phrases with consecutive tokens", func(t *testing.T) {
+		results := newSearchIndex(createTasks()).search(`"buy milk"`)
+
+		asserts.Len(results, 1)
+		asserts.Equal(1, results[0].Task.Id)
+		asserts.Equal([]string{"buy", "milk"}, results[0].Matches)
+	})
+
+	t.Run("✅ Should search the tags of a task", func(t *testing.T) {
+		results := newSearchIndex(createTasks()).search("backend")
+
+		asserts.Len(results, 1)
+		asserts.Equal(3, results[0].Task.Id)
+	})
+
+	t.Run("✅ Should search the notes of a task without matching phrases across fields", func(t *testing.T) {
+		tasks := createTasks()
+		tasks[2].Notes = "Roll back with the old release"
+		index := newSearchIndex(tasks)
+
+		results := index.search(`"old release"`)
+		asserts.Len(results, 1)
+		asserts.Equal(3, results[0].Task.Id)
+
+		asserts.Empty(index.search(`"api roll"`))
+	})
+
+	t.Run("❌ Should return no results when a term does not match", func(t *testing.T) {
+		results := newSearchIndex(createTasks()).search("milk cheese")
+
+		asserts.Empty(results)
+	})
+
+	t.Run("✅ Should rebuild the index after the json store changes", func(t *testing.T) {
+		setup()
+		defer os.Remove(SearchIndexFileName("test.json"))
+
+		taskList := NewJsonTaskStore("test.json")
+		taskList.AddTask(createTask2(1))
+		first, _ := taskList.Search("task")
+		taskList.AddTask(createTask2(2))
+		second, err := taskList.Search("task")
+
+		asserts.Nil(err)
+		asserts.Len(first, 1)
+		asserts.Len(second, 2)
+	})
+
+	t.Run("✅ Should save the index and keep it up to date as the json store changes", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.json")
+		taskList := NewJsonTaskStore(fileName)
+		taskList.AddTask(createTask2(1))
+		taskList.Search("task")
+
+		taskList = NewJsonTaskStore(fileName)
+		taskList.AddTask(createTask2(2))
+		asserts.Nil(taskList.UpdateTask(1, "Renamed"))
+		_, err := taskList.RemoveTask(2)
+		asserts.Nil(err)
+
+		content, _ := os.ReadFile(fileName)
+		index, ok := readSearchIndex(SearchIndexFileName(fileName), checksum(content))
+		asserts.True(ok)
+		asserts.Equal(map[int][]int{1: {0}}, index.postings["renamed"])
+		asserts.Nil(index.postings["task"])
+
+		results, err := NewJsonTaskStore(fileName).Search("renamed")
+		asserts.Nil(err)
+		asserts.Len(results, 1)
+		asserts.Equal("Renamed", results[0].Task.Description)
+	})
+
+	t.Run("✅ Should rebuild a saved index made stale by an outside edit", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "tasks.json")
+		taskList := NewJsonTaskStore(fileName)
+		taskList.AddTask(createTask2(1))
+		taskList.Search("task")
+
+		content, _ := os.ReadFile(fileName)
+		os.WriteFile(fileName, []byte(strings.Replace(string(content), "Task 1", "Edited by hand", 1)), 0644)
+
+		results, err := NewJsonTaskStore(fileName).Search("hand")
+		asserts.Nil(err)
+		asserts.Len(results, 1)
+	})
+}
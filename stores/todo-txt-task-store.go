@@ -0,0 +1,248 @@
+package stores
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"task-tracker/converters"
+	"task-tracker/models"
+	"time"
+)
+
+// TodoTxtTaskStore keeps tasks in a todo.txt file, one line per task with
+// its ID in an id: key. The format keeps dates without time of day and no
+// status history beyond the completion date.
+type TodoTxtTaskStore struct {
+	Tasks    []*models.Task
+	FileName string
+	// unparsed holds the lines without a description, written back as they
+	// were after the tasks.
+	unparsed []string
+	index    *searchIndex
+}
+
+func NewTodoTxtTaskStore(fileName string) *TodoTxtTaskStore {
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		err := os.WriteFile(fileName, nil, 0644)
+
+		if err != nil {
+			panic("Error creating file: " + err.Error())
+		}
+	}
+
+	return &TodoTxtTaskStore{
+		Tasks:    []*models.Task{},
+		FileName: fileName,
+	}
+}
+
+func (s *TodoTxtTaskStore) loadFromFile() error {
+	content, err := os.ReadFile(s.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	tasks := []*models.Task{}
+	unparsed := []string{}
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		task, _, err := converters.ParseTodoTxt(line)
+
+		if err != nil {
+			return fmt.Errorf("invalid todo.txt file %s: line %d: %w", s.FileName, i+1, err)
+		}
+
+		if task.Description == "" {
+			unparsed = append(unparsed, line)
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	// Lines without id or repeating one get new IDs, saved on next write.
+	seen := map[int]bool{}
+	for _, task := range tasks {
+		if seen[task.Id] {
+			task.Id = 0
+		}
+		seen[task.Id] = true
+	}
+
+	s.index = nil
+	s.Tasks = importTasks([]*models.Task{}, tasks)
+	s.unparsed = unparsed
+	return nil
+}
+
+func (s *TodoTxtTaskStore) saveToFile() error {
+	s.index = nil
+	var buffer bytes.Buffer
+
+	err := converters.WriteTodoTxt(&buffer, s.Tasks)
+
+	if err != nil {
+		return err
+	}
+
+	for _, line := range s.unparsed {
+		buffer.WriteString(line + "\n")
+	}
+
+	return os.WriteFile(s.FileName, buffer.Bytes(), 0644)
+}
+
+func (s *TodoTxtTaskStore) AddTask(task *models.Task) (*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	task.Id = 0
+	task.CreatedAt = time.Now()
+	task.Status = models.TODO
+	s.Tasks = importTasks(s.Tasks, []*models.Task{task})
+
+	return task, s.saveToFile()
+}
+
+func (s *TodoTxtTaskStore) ImportTasks(tasks []*models.Task) ([]*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.Tasks = importTasks(s.Tasks, tasks)
+
+	return tasks, s.saveToFile()
+}
+
+func (s *TodoTxtTaskStore) RemoveTask(id int) (*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, task := range s.Tasks {
+		if task.Id == id {
+			s.Tasks = append(s.Tasks[:i], s.Tasks[i+1:]...)
+			return task, s.saveToFile()
+		}
+	}
+	return nil, fmt.Errorf("task with ID %d not found", id)
+}
+
+func (s *TodoTxtTaskStore) UpdateTask(id int, description string) error {
+	return s.update(id, func(task *models.Task) {
+		updatedTime := time.Now()
+		task.UpdatedAt = &updatedTime
+		task.Description = description
+	})
+}
+
+func (s *TodoTxtTaskStore) MarkInProgress(id int) error {
+	return s.update(id, func(task *models.Task) {
+		task.MarkAs(models.IN_PROGRESS)
+	})
+}
+
+func (s *TodoTxtTaskStore) MarkDone(id int) error {
+	return s.update(id, func(task *models.Task) {
+		task.MarkAs(models.DONE)
+	})
+}
+
+func (s *TodoTxtTaskStore) Query(query models.Query) ([]*models.Task, error) {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Apply(s.Tasks), nil
+}
+
+func (s *TodoTxtTaskStore) Search(terms string) ([]models.SearchResult, error) {
+	if s.index == nil {
+		err := s.loadFromFile()
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.index = newSearchIndex(s.Tasks)
+	}
+
+	return s.index.search(terms), nil
+}
+
+func (s *TodoTxtTaskStore) PrintAll() error {
+	return s.print("")
+}
+
+func (s *TodoTxtTaskStore) PrintTodo() error {
+	return s.print(models.TODO)
+}
+
+func (s *TodoTxtTaskStore) PrintDone() error {
+	return s.print(models.DONE)
+}
+
+func (s *TodoTxtTaskStore) PrintInProgress() error {
+	return s.print(models.IN_PROGRESS)
+}
+
+func (s *TodoTxtTaskStore) Files() []string {
+	return []string{s.FileName}
+}
+
+func (s *TodoTxtTaskStore) update(id int, change func(*models.Task)) error {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return err
+	}
+
+	for _, task := range s.Tasks {
+		if task.Id == id {
+			change(task)
+			return s.saveToFile()
+		}
+	}
+	return fmt.Errorf("task with ID %d not found", id)
+}
+
+// print lists the tasks with status, all of them when status is empty.
+func (s *TodoTxtTaskStore) print(status models.Status) error {
+	err := s.loadFromFile()
+
+	if err != nil {
+		return err
+	}
+
+	filteredTasks := models.Query{Filter: func(task *models.Task) bool {
+		return status == "" || task.Status == status
+	}}.Apply(s.Tasks)
+
+	if len(filteredTasks) == 0 {
+		fmt.Println(models.NoTaskString)
+		return nil
+	}
+
+	for _, task := range filteredTasks {
+		task.PrintTask()
+	}
+
+	if status == "" {
+		fmt.Printf("--------------- Total Tasks: %d ---------------\n", len(s.Tasks))
+	}
+	return nil
+}
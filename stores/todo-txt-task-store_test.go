@@ -0,0 +1,78 @@
+package stores
+
+import (
+	"os"
+	"strings"
+	"task-tracker/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoTxtTaskStore(t *testing.T) {
+	asserts := assert.New(t)
+	fileName := "test-todo.txt"
+	defer os.Remove(fileName)
+
+	t.Run("✅ Should add, mark and reload tasks", func(t *testing.T) {
+		os.Remove(fileName)
+
+		store := NewTodoTxtTaskStore(fileName)
+		store.AddTask(&models.Task{Description: "Write docs", Priority: models.HIGH, Tags: []string{"docs"}})
+		store.AddTask(&models.Task{Description: "Ship it"})
+		asserts.Nil(store.MarkInProgress(1))
+		asserts.Nil(store.MarkDone(2))
+
+		tasks, err := NewTodoTxtTaskStore(fileName).Query(models.Query{})
+
+		asserts.Nil(err)
+		asserts.Len(tasks, 2)
+		asserts.Equal(models.IN_PROGRESS, tasks[0].Status)
+		asserts.Equal(models.HIGH, tasks[0].Priority)
+		asserts.Equal([]string{"docs"}, tasks[0].Tags)
+		asserts.Equal(models.DONE, tasks[1].Status)
+	})
+
+	t.Run("✅ Should give lines without or with repeated id a new one", func(t *testing.T) {
+		os.WriteFile(fileName, []byte("First id:3\nSecond\nThird id:3\n"), 0644)
+
+		tasks, err := NewTodoTxtTaskStore(fileName).Query(models.Query{})
+
+		asserts.Nil(err)
+		asserts.Equal(3, tasks[0].Id)
+		asserts.Equal(4, tasks[1].Id)
+		asserts.Equal(5, tasks[2].Id)
+	})
+
+	t.Run("✅ Should write back the lines without description", func(t *testing.T) {
+		os.WriteFile(fileName, []byte("First id:1\n(A) due:2024-09-01 +web\nSecond id:2\n"), 0644)
+		store := NewTodoTxtTaskStore(fileName)
+
+		asserts.Nil(store.MarkDone(1))
+
+		content, _ := os.ReadFile(fileName)
+		asserts.Contains(string(content), "Second id:2\n")
+		asserts.True(strings.HasSuffix(string(content), "\n(A) due:2024-09-01 +web\n"))
+
+		tasks, err := store.Query(models.Query{})
+		asserts.Nil(err)
+		asserts.Len(tasks, 2)
+	})
+
+	t.Run("✅ Should update and remove tasks", func(t *testing.T) {
+		os.WriteFile(fileName, []byte("First id:1\nSecond id:2\n"), 0644)
+		store := NewTodoTxtTaskStore(fileName)
+
+		asserts.Nil(store.UpdateTask(1, "Renamed"))
+		removed, err := store.RemoveTask(2)
+		asserts.Nil(err)
+		asserts.Equal("Second", removed.Description)
+
+		content, _ := os.ReadFile(fileName)
+		asserts.True(strings.HasSuffix(string(content), " Renamed id:1\n"))
+		asserts.Equal(1, strings.Count(string(content), "\n"))
+
+		_, err = store.RemoveTask(9)
+		asserts.EqualError(err, "task with ID 9 not found")
+	})
+}